@@ -0,0 +1,91 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// ClosestApproach will return the time (from now) at which two tracks moving
+// at a constant velocity will be closest together, along with the distance
+// between them at that time.
+//
+// Each track is given by its current location, and its velocity (in Meters
+// per second) in the ENU tangent plane. All the math is done in the WGS84
+// tangent plane at the first track's location, which means this is only
+// really meaningful for tracks that are reasonably close together (as is the
+// case for air or sea traffic conflict detection), since the tangent plane
+// gets less and less accurate the further you get from the reference.
+//
+// Only the future is considered -- if the tracks are moving apart, the time
+// returned is 0, and the distance is the current separation.
+func ClosestApproach(a LLA, va ENU, b LLA, vb ENU) (time.Duration, Meters) {
+	var (
+		r = WGS84().LLAToENU(a, b)
+
+		rx = r.East.F64()
+		ry = r.North.F64()
+		rz = r.Up.F64()
+
+		vx = (vb.East - va.East).F64()
+		vy = (vb.North - va.North).F64()
+		vz = (vb.Up - va.Up).F64()
+
+		vSq = vx*vx + vy*vy + vz*vz
+		t   float64
+	)
+
+	if vSq > 0 {
+		t = math.Max(0, -(rx*vx+ry*vy+rz*vz)/vSq)
+	}
+
+	var (
+		dx = rx + vx*t
+		dy = ry + vy*t
+		dz = rz + vz*t
+	)
+
+	return time.Duration(t * float64(time.Second)), Meters(math.Sqrt(dx*dx + dy*dy + dz*dz))
+}
+
+// DecomposeVelocity will split the velocity (in Meters per second, in the ENU
+// tangent plane at pathStart) into the component along the path from
+// pathStart to pathEnd, and the component across it, positive to the right
+// of the path. The Up component of the velocity is ignored.
+//
+// The direction of the path is the InitialBearing from pathStart, so this is
+// meant to be used at (or near) the start of the path, such as for the
+// current leg of a route being flown. A velocity exactly along the path will
+// have no cross-track component at all.
+func DecomposeVelocity(pathStart, pathEnd LLA, velocity ENU) (Meters, Meters) {
+	var (
+		bearing = InitialBearing(pathStart, pathEnd).Radians().F64()
+		sin     = math.Sin(bearing)
+		cos     = math.Cos(bearing)
+
+		e = velocity.East.F64()
+		n = velocity.North.F64()
+	)
+	return Meters(e*sin + n*cos), Meters(e*cos - n*sin)
+}
+
+// vim: foldmethod=marker
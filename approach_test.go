@@ -0,0 +1,74 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClosestApproachPerpendicular(t *testing.T) {
+	wgs84 := geo.WGS84()
+	a := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+	b := wgs84.XYZToLLA(wgs84.ENUToXYZ(a, geo.ENU{East: 10000}))
+
+	// a is heading North, and b is heading West, both at 10 m/s.
+	when, distance := geo.ClosestApproach(
+		a, geo.ENU{North: 10},
+		b, geo.ENU{East: -10},
+	)
+
+	assert.InEpsilon(t, 500, when.Seconds(), 1e-6)
+	assert.InEpsilon(t, 5000*math.Sqrt2, distance.F64(), 1e-6)
+}
+
+func TestClosestApproachDiverging(t *testing.T) {
+	wgs84 := geo.WGS84()
+	a := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+	b := wgs84.XYZToLLA(wgs84.ENUToXYZ(a, geo.ENU{East: 1000}))
+
+	when, distance := geo.ClosestApproach(
+		a, geo.ENU{East: -10},
+		b, geo.ENU{East: 10},
+	)
+	assert.Equal(t, time.Duration(0), when)
+	assert.InEpsilon(t, 1000, distance.F64(), 1e-6)
+
+	when, distance = geo.ClosestApproach(a, geo.ENU{}, b, geo.ENU{})
+	assert.Equal(t, time.Duration(0), when)
+	assert.InEpsilon(t, 1000, distance.F64(), 1e-6)
+}
+
+func TestDecomposeVelocity(t *testing.T) {
+	var (
+		start = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		end   = geo.DestinationPoint(start, 30, 10000)
+		along = geo.AER{Azimuth: 30, Range: 50}.ENU()
+	)
+
+	alongTrack, crossTrack := geo.DecomposeVelocity(start, end, along)
+	assert.InEpsilon(t, 50, alongTrack.F64(), 1e-9)
+	assert.InDelta(t, 0, crossTrack.F64(), 1e-9)
+
+	// Drifting off to the right of the path.
+	alongTrack, crossTrack = geo.DecomposeVelocity(start, end, geo.AER{Azimuth: 120, Range: 5}.ENU())
+	assert.InDelta(t, 0, alongTrack.F64(), 1e-9)
+	assert.InEpsilon(t, 5, crossTrack.F64(), 1e-9)
+
+	// Going backwards, and drifting left, with climbing ignored.
+	var (
+		backwards = geo.AER{Azimuth: 210, Range: 20}.ENU()
+		left      = geo.AER{Azimuth: 300, Range: 3}.ENU()
+		velocity  = geo.ENU{
+			East:  backwards.East + left.East,
+			North: backwards.North + left.North,
+			Up:    10,
+		}
+	)
+	alongTrack, crossTrack = geo.DecomposeVelocity(start, end, velocity)
+	assert.InEpsilon(t, -20, alongTrack.F64(), 1e-9)
+	assert.InEpsilon(t, -3, crossTrack.F64(), 1e-9)
+}
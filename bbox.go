@@ -0,0 +1,132 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"sort"
+)
+
+// BoundingBox is a box aligned to lines of Latitude and Longitude, given by
+// its South and North Latitudes, and its West and East Longitudes.
+//
+// A BoundingBox that crosses the anti-meridian has a West Longitude greater
+// than its East Longitude -- for instance, a box from 170 to -170 is 20
+// Degrees wide, not 340.
+type BoundingBox struct {
+	South Degrees
+	West  Degrees
+	North Degrees
+	East  Degrees
+}
+
+// CrossesAntiMeridian will return true if the BoundingBox spans the
+// anti-meridian (the West Longitude is greater than the East Longitude).
+func (b BoundingBox) CrossesAntiMeridian() bool {
+	return b.West > b.East
+}
+
+// Center will return the point in the middle of the BoundingBox, with an
+// Altitude of 0.
+func (b BoundingBox) Center() LLA {
+	east := b.East
+	if b.CrossesAntiMeridian() {
+		east += 360
+	}
+	return LLA{
+		Latitude:  (b.South + b.North) / 2,
+		Longitude: normalizeLongitude((b.West + east) / 2),
+	}
+}
+
+// Contains will return true if the point is within the BoundingBox, edges
+// included. Altitude is ignored.
+func (b BoundingBox) Contains(point LLA) bool {
+	if point.Latitude < b.South || point.Latitude > b.North {
+		return false
+	}
+	lon := normalizeLongitude(point.Longitude)
+	if b.CrossesAntiMeridian() {
+		return lon >= b.West || lon <= b.East
+	}
+	return lon >= b.West && lon <= b.East
+}
+
+// ToPolygon will return the four corners of the BoundingBox as a Polygon,
+// going counter-clockwise from the South-West corner.
+//
+// Note that the edges of the Polygon are the edges of the BoundingBox, which
+// follow lines of Latitude, not great circles.
+func (b BoundingBox) ToPolygon() Polygon {
+	return Polygon{
+		{Latitude: b.South, Longitude: b.West},
+		{Latitude: b.South, Longitude: b.East},
+		{Latitude: b.North, Longitude: b.East},
+		{Latitude: b.North, Longitude: b.West},
+	}
+}
+
+// BoundingBox will return the smallest BoundingBox that contains all the
+// vertices of the Polygon.
+//
+// The Longitudes are treated as points around a circle, and the box is the
+// shortest arc that covers all of them, so Polygons that cross the
+// anti-meridian get a BoundingBox that crosses it too. Since only the
+// vertices are considered, Polygons that contain a pole (or that have very
+// long edges, which bow away from the Equator along great circles) are not
+// covered exactly.
+func (p Polygon) BoundingBox() BoundingBox {
+	if len(p) == 0 {
+		return BoundingBox{}
+	}
+
+	var (
+		box  = BoundingBox{South: p[0].Latitude, North: p[0].Latitude}
+		lons = make([]float64, len(p))
+	)
+
+	for i, point := range p {
+		if point.Latitude < box.South {
+			box.South = point.Latitude
+		}
+		if point.Latitude > box.North {
+			box.North = point.Latitude
+		}
+		lons[i] = normalizeLongitude(point.Longitude).F64()
+	}
+	sort.Float64s(lons)
+
+	// The box is everything but the largest gap between two Longitudes,
+	// starting with the gap that wraps around the anti-meridian.
+	gap := lons[0] + 360 - lons[len(lons)-1]
+	box.West = Degrees(lons[0])
+	box.East = Degrees(lons[len(lons)-1])
+
+	for i := 1; i < len(lons); i++ {
+		if lons[i]-lons[i-1] > gap {
+			gap = lons[i] - lons[i-1]
+			box.West = Degrees(lons[i])
+			box.East = Degrees(lons[i-1])
+		}
+	}
+	return box
+}
+
+// vim: foldmethod=marker
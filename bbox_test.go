@@ -0,0 +1,70 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundingBoxToPolygon(t *testing.T) {
+	box := geo.BoundingBox{South: 10, West: 20, North: 11, East: 22}
+	polygon := box.ToPolygon()
+
+	assert.Len(t, polygon, 4)
+	assert.True(t, polygon.Contains(geo.LLA{Latitude: 10.5, Longitude: 21}))
+	assert.False(t, polygon.Contains(geo.LLA{Latitude: 10.5, Longitude: 23}))
+	assert.Equal(t, box, polygon.BoundingBox())
+
+	dateLine := geo.BoundingBox{South: -5, West: 170, North: 5, East: -170}
+	assert.True(t, dateLine.CrossesAntiMeridian())
+	polygon = dateLine.ToPolygon()
+	assert.True(t, polygon.Contains(geo.LLA{Latitude: 0, Longitude: 180}))
+	assert.False(t, polygon.Contains(geo.LLA{Latitude: 0, Longitude: 0}))
+	assert.Equal(t, dateLine, polygon.BoundingBox())
+}
+
+func TestPolygonBoundingBox(t *testing.T) {
+	polygon := geo.Polygon{
+		{Latitude: 1, Longitude: 178},
+		{Latitude: 3, Longitude: -179},
+		{Latitude: -2, Longitude: -175},
+		{Latitude: -1, Longitude: 179},
+	}
+
+	box := polygon.BoundingBox()
+	assert.Equal(t, geo.BoundingBox{South: -2, West: 178, North: 3, East: -175}, box)
+	assert.True(t, box.CrossesAntiMeridian())
+
+	polygon = geo.Polygon{
+		{Latitude: 1, Longitude: -10},
+		{Latitude: 3, Longitude: 10},
+		{Latitude: -2, Longitude: 5},
+	}
+	box = polygon.BoundingBox()
+	assert.Equal(t, geo.BoundingBox{South: -2, West: -10, North: 3, East: 10}, box)
+	assert.False(t, box.CrossesAntiMeridian())
+}
+
+func TestBoundingBoxCenter(t *testing.T) {
+	box := geo.BoundingBox{South: 10, West: 20, North: 12, East: 24}
+	assert.Equal(t, geo.LLA{Latitude: 11, Longitude: 22}, box.Center())
+
+	box = geo.BoundingBox{South: -5, West: 170, North: 5, East: -150}
+	assert.Equal(t, geo.LLA{Latitude: 0, Longitude: -170}, box.Center())
+}
+
+func TestBoundingBoxContains(t *testing.T) {
+	box := geo.BoundingBox{South: 10, West: 20, North: 11, East: 22}
+	assert.True(t, box.Contains(geo.LLA{Latitude: 10.5, Longitude: 21}))
+	assert.True(t, box.Contains(geo.LLA{Latitude: 10, Longitude: 20}))
+	assert.False(t, box.Contains(geo.LLA{Latitude: 12, Longitude: 21}))
+	assert.False(t, box.Contains(geo.LLA{Latitude: 10.5, Longitude: 23}))
+
+	dateLine := geo.BoundingBox{South: -5, West: 170, North: 5, East: -170}
+	assert.True(t, dateLine.Contains(geo.LLA{Latitude: 0, Longitude: 175}))
+	assert.True(t, dateLine.Contains(geo.LLA{Latitude: 0, Longitude: -175}))
+	assert.True(t, dateLine.Contains(geo.LLA{Latitude: 0, Longitude: 185}))
+	assert.False(t, dateLine.Contains(geo.LLA{Latitude: 0, Longitude: 0}))
+}
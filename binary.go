@@ -0,0 +1,59 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// aerBinaryLength is the number of bytes in the wire format of an AER.
+const aerBinaryLength = 24
+
+// MarshalBinary will encode the AER into a fixed 24 byte wire format, made
+// up of the Azimuth (in Degrees), Elevation (in Degrees) and Range (in
+// Meters), in that order, each as a big-endian IEEE 754 float64.
+//
+// This implements encoding.BinaryMarshaler.
+func (aed AER) MarshalBinary() ([]byte, error) {
+	data := make([]byte, aerBinaryLength)
+	binary.BigEndian.PutUint64(data[0:8], math.Float64bits(aed.Azimuth.F64()))
+	binary.BigEndian.PutUint64(data[8:16], math.Float64bits(aed.Elevation.F64()))
+	binary.BigEndian.PutUint64(data[16:24], math.Float64bits(aed.Range.F64()))
+	return data, nil
+}
+
+// UnmarshalBinary will decode an AER from the wire format written by
+// AER.MarshalBinary. The data must be exactly 24 bytes long.
+//
+// This implements encoding.BinaryUnmarshaler.
+func (aed *AER) UnmarshalBinary(data []byte) error {
+	if len(data) != aerBinaryLength {
+		return fmt.Errorf("geo.AER.UnmarshalBinary: expected %d bytes, got %d", aerBinaryLength, len(data))
+	}
+	aed.Azimuth = Degrees(math.Float64frombits(binary.BigEndian.Uint64(data[0:8])))
+	aed.Elevation = Degrees(math.Float64frombits(binary.BigEndian.Uint64(data[8:16])))
+	aed.Range = Meters(math.Float64frombits(binary.BigEndian.Uint64(data[16:24])))
+	return nil
+}
+
+// vim: foldmethod=marker
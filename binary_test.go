@@ -0,0 +1,32 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAERMarshalBinary(t *testing.T) {
+	data, err := geo.AER{Azimuth: 90, Elevation: -1.5, Range: 1000}.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{
+		0x40, 0x56, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0xbf, 0xf8, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x40, 0x8f, 0x40, 0x00, 0x00, 0x00, 0x00, 0x00,
+	}, data)
+}
+
+func TestAERUnmarshalBinary(t *testing.T) {
+	aer := geo.AER{Azimuth: 123.456, Elevation: 12.5, Range: 31337.1}
+	data, err := aer.MarshalBinary()
+	assert.NoError(t, err)
+
+	var decoded geo.AER
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, aer, decoded)
+
+	assert.Error(t, decoded.UnmarshalBinary(data[:23]))
+	assert.Error(t, decoded.UnmarshalBinary(append(data, 0)))
+}
@@ -0,0 +1,139 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// capEpsilon is the slack (in Radians) given when checking if a point is
+// inside of a spherical cap, to keep points that are exactly on the boundary
+// from being rejected due to floating point noise.
+const capEpsilon = 1e-12
+
+// sphericalCap is a region of the sphere within an angular radius of the
+// center unit vector.
+type sphericalCap struct {
+	center vector
+	radius Radians
+}
+
+func (c sphericalCap) contains(v vector) bool {
+	return c.center.angle(v) <= c.radius+capEpsilon
+}
+
+// capFromTwo will return the smallest cap with both points on the boundary.
+func capFromTwo(a, b vector) sphericalCap {
+	center := a.add(b).unit()
+	return sphericalCap{center: center, radius: center.angle(a)}
+}
+
+// capFromThree will return the smallest cap with all three points on the
+// boundary.
+func capFromThree(a, b, c vector) sphericalCap {
+	center := b.sub(a).cross(c.sub(a))
+	if center.norm() == 0 {
+		// The points are degenerate (at least two of them are the same
+		// point), so the cap through the remaining two will do.
+		enclosing := capFromTwo(a, b)
+		if cc := capFromTwo(a, c); cc.radius > enclosing.radius {
+			enclosing = cc
+		}
+		if cc := capFromTwo(b, c); cc.radius > enclosing.radius {
+			enclosing = cc
+		}
+		return enclosing
+	}
+	if center.dot(a) < 0 {
+		center = center.scale(-1)
+	}
+	center = center.unit()
+	return sphericalCap{center: center, radius: center.angle(a)}
+}
+
+// InCap will return true if the point is within the angular radius of the
+// center, on a sphere. Points exactly on the boundary are inside the cap.
+//
+// Since this only cares about angles, it's not bothered by Altitude (which
+// is ignored) or the size of the Earth, which makes it a cheap check for
+// things like a sensor's field of regard.
+func InCap(center LLA, angularRadius Degrees, point LLA) bool {
+	return sphericalCap{
+		center: unitVector(center),
+		radius: angularRadius.Radians(),
+	}.contains(unitVector(point))
+}
+
+// MinimumEnclosingCap will return the smallest spherical cap (a center point,
+// and an angular radius around it) that contains all of the provided points.
+//
+// This is the spherical analog of the smallest enclosing circle. All the math
+// is done on unit vectors rather than Latitude and Longitude, so clusters of
+// points that straddle the anti-meridian (or a pole) are handled without any
+// special casing. Altitude is ignored, and the returned center has an
+// Altitude of 0.
+//
+// The points must fit within a hemisphere -- if they don't (or if no points
+// were provided at all), an error is returned.
+func MinimumEnclosingCap(points []LLA) (LLA, Degrees, error) {
+	if len(points) == 0 {
+		return LLA{}, 0, fmt.Errorf("geo.MinimumEnclosingCap: no points provided")
+	}
+
+	vectors := make([]vector, len(points))
+	for i, point := range points {
+		vectors[i] = unitVector(point)
+	}
+
+	enclosing := sphericalCap{center: vectors[0]}
+	for i := 1; i < len(vectors); i++ {
+		if enclosing.contains(vectors[i]) {
+			continue
+		}
+		enclosing = sphericalCap{center: vectors[i]}
+		for j := 0; j < i; j++ {
+			if enclosing.contains(vectors[j]) {
+				continue
+			}
+			enclosing = capFromTwo(vectors[i], vectors[j])
+			for k := 0; k < j; k++ {
+				if enclosing.contains(vectors[k]) {
+					continue
+				}
+				enclosing = capFromThree(vectors[i], vectors[j], vectors[k])
+			}
+		}
+	}
+
+	if enclosing.radius >= math.Pi/2 || enclosing.center.norm() == 0 {
+		return LLA{}, 0, fmt.Errorf("geo.MinimumEnclosingCap: points do not fit within a hemisphere")
+	}
+	for _, v := range vectors {
+		if !enclosing.contains(v) {
+			return LLA{}, 0, fmt.Errorf("geo.MinimumEnclosingCap: points do not fit within a hemisphere")
+		}
+	}
+
+	return enclosing.center.lla(), enclosing.radius.Degrees(), nil
+}
+
+// vim: foldmethod=marker
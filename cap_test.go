@@ -0,0 +1,81 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// angularDistance is the great-circle distance between two points, as an
+// angle, using the same spherical Earth as HaversineDistance.
+func angularDistance(t *testing.T, a, b geo.LLA) geo.Degrees {
+	meters, err := geo.HaversineDistance(a, b)
+	assert.NoError(t, err)
+	return geo.Radians(meters.F64() / 6371000).Degrees()
+}
+
+func TestMinimumEnclosingCap(t *testing.T) {
+	points := []geo.LLA{
+		{Latitude: 10, Longitude: 20},
+		{Latitude: 12, Longitude: 21},
+		{Latitude: 9, Longitude: 23},
+		{Latitude: 11, Longitude: 22},
+		{Latitude: 14, Longitude: 19},
+	}
+
+	center, radius, err := geo.MinimumEnclosingCap(points)
+	assert.NoError(t, err)
+
+	var max geo.Degrees
+	for _, point := range points {
+		if d := angularDistance(t, center, point); d > max {
+			max = d
+		}
+	}
+	assert.InEpsilon(t, max.F64(), radius.F64(), 1e-6)
+}
+
+func TestMinimumEnclosingCapAntiMeridian(t *testing.T) {
+	points := []geo.LLA{
+		{Latitude: 0, Longitude: 179},
+		{Latitude: 0, Longitude: -179},
+	}
+
+	center, radius, err := geo.MinimumEnclosingCap(points)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, center.Latitude.F64(), 1e-9)
+	assert.InDelta(t, 180, math.Abs(center.Longitude.F64()), 1e-9)
+	assert.InDelta(t, 1, radius.F64(), 1e-9)
+}
+
+func TestMinimumEnclosingCapInvalid(t *testing.T) {
+	_, _, err := geo.MinimumEnclosingCap(nil)
+	assert.Error(t, err)
+
+	_, _, err = geo.MinimumEnclosingCap([]geo.LLA{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 90},
+		{Latitude: 0, Longitude: 180},
+		{Latitude: 0, Longitude: -90},
+		{Latitude: 90},
+		{Latitude: -90},
+	})
+	assert.Error(t, err)
+}
+
+func TestInCap(t *testing.T) {
+	center := geo.LLA{Latitude: 0, Longitude: 0}
+
+	assert.True(t, geo.InCap(center, 10, geo.LLA{Latitude: 9.999, Longitude: 0}))
+	assert.False(t, geo.InCap(center, 10, geo.LLA{Latitude: 10.001, Longitude: 0}))
+	assert.True(t, geo.InCap(center, 10, geo.LLA{Latitude: 0, Longitude: -10}))
+	assert.True(t, geo.InCap(center, 10, geo.LLA{Latitude: 0, Longitude: 0, Altitude: 1e6}))
+
+	// Around the pole and across the anti-meridian.
+	pole := geo.LLA{Latitude: 89, Longitude: 179}
+	assert.True(t, geo.InCap(pole, 3, geo.LLA{Latitude: 89, Longitude: -1}))
+	assert.False(t, geo.InCap(pole, 1, geo.LLA{Latitude: 89, Longitude: -1}))
+}
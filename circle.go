@@ -0,0 +1,118 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Circle is every point on the surface within Radius of the Center, such as a
+// keep-out zone around an obstacle. Distances are great circle distances
+// along the same spherical Earth as HaversineDistance, and Altitude is
+// ignored.
+type Circle struct {
+	Center LLA
+	Radius Meters
+}
+
+// Contains will return true if the point is within the Circle. Points
+// exactly on the edge of the Circle are inside of it.
+func (c Circle) Contains(point LLA) bool {
+	return haversine(c.Center, point) <= c.Radius
+}
+
+// NearestBoundaryPoint will return the point on the edge of the Circle that
+// is closest to the point, which is the Radius away from the Center, along
+// the bearing from the Center to the point. This works the same whether the
+// point is inside or outside of the Circle.
+//
+// If the point is right at the Center, every point on the edge is just as
+// close, and the one due North of the Center is returned. The Altitude of the
+// Center is carried over to the returned point, just like DestinationPoint.
+func (c Circle) NearestBoundaryPoint(point LLA) LLA {
+	var bearing Degrees
+	if haversine(c.Center, point) != 0 {
+		bearing = InitialBearing(c.Center, point)
+	}
+	return DestinationPoint(c.Center, bearing, c.Radius)
+}
+
+// BoundingBox will return the smallest BoundingBox that contains the whole
+// Circle. If the Circle reaches over a pole, the BoundingBox covers every
+// Longitude, from -180 to 180.
+func (c Circle) BoundingBox() BoundingBox {
+	var (
+		r     = Radians(c.Radius.F64() / earthRadiusMeters).Degrees()
+		south = c.Center.Latitude - r
+		north = c.Center.Latitude + r
+	)
+
+	if south <= -90 || north >= 90 {
+		return BoundingBox{
+			South: Degrees(math.Max(-90, south.F64())),
+			West:  -180,
+			North: Degrees(math.Min(90, north.F64())),
+			East:  180,
+		}
+	}
+
+	// The widest point of the Circle isn't at the Latitude of the Center,
+	// but where the meridians are tangent to it.
+	dLon := Radians(math.Asin(
+		math.Sin(r.Radians().F64()) / math.Cos(c.Center.Latitude.Radians().F64()),
+	)).Degrees()
+
+	return BoundingBox{
+		South: south,
+		West:  normalizeLongitude(c.Center.Longitude - dLon),
+		North: north,
+		East:  normalizeLongitude(c.Center.Longitude + dLon),
+	}
+}
+
+// TangentBearings will return the two bearings (in Degrees clockwise from
+// North, within [0, 360)) from the point that just graze the edge of the
+// Circle -- left is the tangent counter-clockwise of the bearing to the
+// Center, and right is the tangent clockwise of it. Any bearing between the
+// two will run into the Circle.
+//
+// If the point is inside the Circle, there's no way to graze it, and an error
+// is returned.
+func TangentBearings(from LLA, circle Circle) (left, right Degrees, err error) {
+	if circle.Contains(from) {
+		return 0, 0, fmt.Errorf("geo.TangentBearings: point is inside the Circle")
+	}
+
+	var (
+		d       = haversine(from, circle.Center).F64() / earthRadiusMeters
+		r       = circle.Radius.F64() / earthRadiusMeters
+		bearing = InitialBearing(from, circle.Center)
+
+		// The tangent, the line to the Center, and the radius to the tangent
+		// point make a right spherical triangle.
+		theta = Radians(math.Asin(math.Min(1, math.Sin(r)/math.Sin(d)))).Degrees()
+	)
+
+	return normalizeBearing(bearing - theta), normalizeBearing(bearing + theta), nil
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,84 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircleContains(t *testing.T) {
+	circle := geo.Circle{Center: geo.LLA{Latitude: 10, Longitude: 20}, Radius: 1000}
+
+	assert.True(t, circle.Contains(circle.Center))
+	assert.True(t, circle.Contains(geo.DestinationPoint(circle.Center, 45, 999)))
+	assert.False(t, circle.Contains(geo.DestinationPoint(circle.Center, 45, 1001)))
+}
+
+func TestCircleNearestBoundaryPoint(t *testing.T) {
+	circle := geo.Circle{Center: geo.LLA{Latitude: 10, Longitude: 20}, Radius: 1000}
+
+	// Something 5km to the East snaps to the Eastern edge.
+	outside := geo.DestinationPoint(circle.Center, 90, 5000)
+	edge := circle.NearestBoundaryPoint(outside)
+	expected := geo.DestinationPoint(circle.Center, 90, 1000)
+	assert.InDelta(t, expected.Latitude.F64(), edge.Latitude.F64(), 1e-9)
+	assert.InDelta(t, expected.Longitude.F64(), edge.Longitude.F64(), 1e-9)
+
+	// Inside works just the same.
+	edge = circle.NearestBoundaryPoint(geo.DestinationPoint(circle.Center, 200, 10))
+	expected = geo.DestinationPoint(circle.Center, 200, 1000)
+	assert.InDelta(t, expected.Latitude.F64(), edge.Latitude.F64(), 1e-9)
+	assert.InDelta(t, expected.Longitude.F64(), edge.Longitude.F64(), 1e-9)
+
+	// And the Center picks due North.
+	assert.Equal(t, geo.DestinationPoint(circle.Center, 0, 1000), circle.NearestBoundaryPoint(circle.Center))
+}
+
+func TestTangentBearings(t *testing.T) {
+	var (
+		from   = geo.LLA{Latitude: 10, Longitude: 20}
+		circle = geo.Circle{Center: geo.DestinationPoint(from, 0, 10000), Radius: 1000}
+		theta  = geo.Radians(math.Asin(0.1)).Degrees().F64()
+	)
+
+	left, right, err := geo.TangentBearings(from, circle)
+	assert.NoError(t, err)
+	assert.InDelta(t, 360-theta, left.F64(), 1e-3)
+	assert.InDelta(t, theta, right.F64(), 1e-3)
+
+	// Heading off along either tangent should just graze the edge.
+	for _, bearing := range []geo.Degrees{left, right} {
+		closest := math.Inf(1)
+		for i := 0; i <= 200; i++ {
+			d, err := geo.HaversineDistance(
+				geo.DestinationPoint(from, bearing, geo.Meters(9000+10*i)),
+				circle.Center,
+			)
+			assert.NoError(t, err)
+			closest = math.Min(closest, d.F64())
+		}
+		assert.InDelta(t, 1000, closest, 1)
+	}
+
+	_, _, err = geo.TangentBearings(circle.Center, circle)
+	assert.Error(t, err)
+}
+
+func TestCircleBoundingBox(t *testing.T) {
+	circle := geo.Circle{Center: geo.LLA{Latitude: 60, Longitude: 179.9}, Radius: 10000}
+	bb := circle.BoundingBox()
+	assert.True(t, bb.CrossesAntiMeridian())
+
+	for bearing := geo.Degrees(0); bearing < 360; bearing += 5 {
+		assert.True(t, bb.Contains(geo.DestinationPoint(circle.Center, bearing, 9999)))
+	}
+	assert.False(t, bb.Contains(geo.DestinationPoint(circle.Center, 0, 10100)))
+	assert.False(t, bb.Contains(geo.DestinationPoint(circle.Center, 90, 10100)))
+
+	// Reaching over the pole covers every Longitude.
+	polar := geo.Circle{Center: geo.LLA{Latitude: 89.95, Longitude: 0}, Radius: 10000}
+	assert.Equal(t, geo.BoundingBox{South: 89.95 - geo.Radians(10000.0/6371000).Degrees(), West: -180, North: 90, East: 180}, polar.BoundingBox())
+}
@@ -0,0 +1,191 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseAngle will parse a single angle in decimal degrees ("-77.0365"),
+// degrees and decimal minutes ("77 02.19 W"), or degrees, minutes and
+// seconds ("77°02'11.6\"W"), telling them apart by the number of fields.
+// The hemisphere letter (or 0 if there wasn't one) is returned too.
+func parseAngle(s string) (Degrees, byte, error) {
+	rest, sign, hemisphere := splitHemisphere(s)
+	fields := angleFields(rest)
+
+	switch len(fields) {
+	case 1:
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid decimal degrees in %q", s)
+		}
+		if value < 0 && hemisphere != 0 {
+			return 0, 0, fmt.Errorf("both a sign and a hemisphere given in %q", s)
+		}
+		return Degrees(sign * value), hemisphere, nil
+	case 2:
+		value, err := ParseDMM(s)
+		if err != nil {
+			return 0, 0, err
+		}
+		return value, hemisphere, nil
+	case 3:
+		if strings.HasPrefix(fields[0], "-") {
+			if hemisphere != 0 {
+				return 0, 0, fmt.Errorf("both a sign and a hemisphere given in %q", s)
+			}
+			sign = -1
+		}
+		degrees, err := strconv.ParseUint(strings.TrimLeft(fields[0], "+-"), 10, 16)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid degrees in %q", s)
+		}
+		minutes, err := strconv.ParseUint(fields[1], 10, 8)
+		if err != nil || minutes >= 60 {
+			return 0, 0, fmt.Errorf("invalid minutes in %q", s)
+		}
+		seconds, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil || seconds < 0 || seconds >= 60 {
+			return 0, 0, fmt.Errorf("invalid seconds in %q", s)
+		}
+		return Degrees(sign * (float64(degrees) + float64(minutes)/60 + seconds/3600)), hemisphere, nil
+	default:
+		return 0, 0, fmt.Errorf("unable to make sense of the angle %q", s)
+	}
+}
+
+// isHemisphere will return true if the character is a hemisphere letter.
+func isHemisphere(c byte) bool {
+	switch c {
+	case 'N', 'S', 'E', 'W', 'n', 's', 'e', 'w':
+		return true
+	}
+	return false
+}
+
+// angleMarkers are the degree, minute and second markers a hemisphere letter
+// can sit right next to, as in 38°53'52.6"N.
+var angleMarkers = []string{"°", "'", "\"", "′", "″"}
+
+// isHemisphereAt will return true if the character at i is a hemisphere
+// letter that either starts or ends a whitespace separated token, or sits
+// right next to an angle marker. This keeps letters in the middle of a
+// number (such as the e of an exponent) from being taken for a hemisphere.
+func isHemisphereAt(s string, i int) bool {
+	if !isHemisphere(s[i]) {
+		return false
+	}
+	if i == 0 || i == len(s)-1 || s[i-1] == ' ' || s[i-1] == '\t' || s[i+1] == ' ' || s[i+1] == '\t' {
+		return true
+	}
+	for _, marker := range angleMarkers {
+		if strings.HasSuffix(s[:i], marker) || strings.HasPrefix(s[i+1:], marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCoordinate will split a coordinate string into the two angles, either
+// at the comma, after the first hemisphere letter, or (for plain decimal
+// degrees) at the whitespace.
+func splitCoordinate(s string) ([]string, error) {
+	if parts := strings.Split(s, ","); len(parts) == 2 {
+		return parts, nil
+	} else if len(parts) > 2 {
+		return nil, fmt.Errorf("too many commas in %q", s)
+	}
+
+	// No comma, so split on the hemisphere letters, which will either
+	// lead ("N38 53.7 W77 02.2") or trail ("38 53.7 N 77 02.2 W") each of
+	// the angles. Only letters on the edge of a token count, so that
+	// exponents ("1e-3") aren't mistaken for a hemisphere.
+	var (
+		trimmed = strings.TrimSpace(s)
+		leading = len(trimmed) > 0 && isHemisphereAt(trimmed, 0)
+	)
+	for i := 1; i < len(trimmed)-1; i++ {
+		if !isHemisphereAt(trimmed, i) {
+			continue
+		}
+		if leading {
+			return []string{trimmed[:i], trimmed[i:]}, nil
+		}
+		return []string{trimmed[:i+1], trimmed[i+1:]}, nil
+	}
+
+	if fields := strings.Fields(trimmed); len(fields) == 2 {
+		return fields, nil
+	}
+	return nil, fmt.Errorf("unable to tell where the Latitude ends and the Longitude begins in %q", s)
+}
+
+// ParseCoordinate will parse a Latitude and Longitude pasted in from just
+// about anywhere, such as "38.8979, -77.0365", "38 53.8746 N, 77 02.1936 W",
+// or "38°53'52.6\"N 77°02'11.6\"W". Each angle may be in decimal degrees,
+// degrees and decimal minutes, or degrees, minutes and seconds, with either
+// a sign or a hemisphere letter (but not both).
+//
+// The Latitude is expected to come first, unless hemisphere letters say
+// otherwise ("77 W, 38 N" is fine). If the string can't be split up into two
+// angles, or the angles are out of range, an error is returned. The returned
+// LLA has an Altitude of 0.
+func ParseCoordinate(s string) (LLA, error) {
+	parts, err := splitCoordinate(s)
+	if err != nil {
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: %s", err)
+	}
+
+	first, firstHemisphere, err := parseAngle(parts[0])
+	if err != nil {
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: %s", err)
+	}
+	second, secondHemisphere, err := parseAngle(parts[1])
+	if err != nil {
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: %s", err)
+	}
+
+	isLatitude := func(h byte) bool { return h == 'N' || h == 'S' }
+	isLongitude := func(h byte) bool { return h == 'E' || h == 'W' }
+
+	switch {
+	case isLongitude(firstHemisphere) && !isLongitude(secondHemisphere):
+		first, second = second, first
+	case isLatitude(secondHemisphere) && !isLatitude(firstHemisphere):
+		first, second = second, first
+	case isLatitude(firstHemisphere) && isLatitude(secondHemisphere),
+		isLongitude(firstHemisphere) && isLongitude(secondHemisphere):
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: both angles are in the same axis in %q", s)
+	}
+
+	if first < -90 || first > 90 {
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: Latitude out of range in %q", s)
+	}
+	if second < -180 || second > 180 {
+		return LLA{}, fmt.Errorf("geo.ParseCoordinate: Longitude out of range in %q", s)
+	}
+	return LLA{Latitude: first, Longitude: second}, nil
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,52 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinate(t *testing.T) {
+	expected := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+
+	for _, input := range []string{
+		"38.897957, -77.036560",
+		"38.897957 -77.036560",
+		"38.897957N, 77.036560W",
+		"38 53.87742 N, 77 02.1936 W",
+		"N38°53.87742' W77°02.1936'",
+		"38°53'52.6452\"N 77°02'11.616\"W",
+		"38 53 52.6452, -77 02 11.616",
+		"77 02 11.616 W, 38 53 52.6452 N",
+	} {
+		lla, err := geo.ParseCoordinate(input)
+		assert.NoError(t, err, input)
+		assert.True(t, lla.ApproxEqual(expected, 1e-6, 0), input)
+	}
+
+	// The e of an exponent isn't a hemisphere.
+	lla, err := geo.ParseCoordinate("1e-3 2e-3")
+	assert.NoError(t, err)
+	assert.True(t, lla.ApproxEqual(geo.LLA{Latitude: 0.001, Longitude: 0.002}, 1e-12, 0))
+}
+
+func TestParseCoordinateErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"38.8979",
+		"38.8979, -77.0365, 100",
+		"38 53 52 77 02 11",
+		"38 53.87742 -77 02.1936",
+		"38.8979 N, 77.0365 N",
+		"-38.8979 N, 77.0365 W",
+		"98.8979, -77.0365",
+		"38.8979, -197.0365",
+		"38 61 N, 77 02 W",
+		"thirty eight, seventy seven",
+	} {
+		_, err := geo.ParseCoordinate(input)
+		assert.Error(t, err, input)
+	}
+}
@@ -0,0 +1,58 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+// RotateCovarianceENUToECEF will rotate a 3x3 covariance matrix in the ENU
+// tangent plane at ref (with rows and columns in East, North, Up order) into
+// a covariance matrix in XYZ (ECEF) space (with rows and columns in X, Y, Z
+// order), which is R * cov * R^T, where R is the rotation from ENU to XYZ.
+//
+// Since this is only a rotation, the covariance is the same shape (and size)
+// -- an uncertainty that's the same in every direction will stay that way.
+// The Latitude is the angle of the surface normal, so this works for the
+// tangent plane of any CoordinateSystem.
+func RotateCovarianceENUToECEF(ref LLA, cov [3][3]float64) [3][3]float64 {
+	var (
+		east, north, up = enuBasis(ref)
+
+		r = [3][3]float64{
+			{east.x, north.x, up.x},
+			{east.y, north.y, up.y},
+			{east.z, north.z, up.z},
+		}
+		rotated [3][3]float64
+	)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			var sum float64
+			for k := 0; k < 3; k++ {
+				for l := 0; l < 3; l++ {
+					sum += r[i][k] * cov[k][l] * r[j][l]
+				}
+			}
+			rotated[i][j] = sum
+		}
+	}
+	return rotated
+}
+
+// vim: foldmethod=marker
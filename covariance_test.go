@@ -0,0 +1,54 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateCovarianceENUToECEFIsotropic(t *testing.T) {
+	identity := [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+
+	for _, ref := range []geo.LLA{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 38.897957, Longitude: -77.036560},
+		{Latitude: -89, Longitude: 151},
+	} {
+		rotated := geo.RotateCovarianceENUToECEF(ref, identity)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				assert.InDelta(t, identity[i][j], rotated[i][j], 1e-12)
+			}
+		}
+	}
+}
+
+func TestRotateCovarianceENUToECEF(t *testing.T) {
+	// At Latitude 0, Longitude 0, East is +Y, North is +Z, and Up is +X.
+	rotated := geo.RotateCovarianceENUToECEF(
+		geo.LLA{Latitude: 0, Longitude: 0},
+		[3][3]float64{{4, 0, 0}, {0, 9, 0}, {0, 0, 16}},
+	)
+	assert.InDelta(t, 16, rotated[0][0], 1e-12)
+	assert.InDelta(t, 4, rotated[1][1], 1e-12)
+	assert.InDelta(t, 9, rotated[2][2], 1e-12)
+
+	// A point's uncertainty in Up, expressed in XYZ, should follow the
+	// direction of the ENU Up axis.
+	var (
+		wgs  = geo.WGS84()
+		ref  = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		base = wgs.LLAToXYZ(ref)
+		up   = wgs.ENUToXYZ(ref, geo.ENU{Up: 1})
+		u    = [3]float64{(up.X - base.X).F64(), (up.Y - base.Y).F64(), (up.Z - base.Z).F64()}
+	)
+
+	rotated = geo.RotateCovarianceENUToECEF(ref, [3][3]float64{{0, 0, 0}, {0, 0, 0}, {0, 0, 1}})
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			assert.InDelta(t, u[i]*u[j], rotated[i][j], 1e-9)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// splitHemisphere will remove a leading or trailing hemisphere letter (N, S,
+// E or W) from the string, returning the rest of the string, the sign that
+// hemisphere implies, and the (upper case) letter itself, or 0 if there was
+// no hemisphere letter at all.
+func splitHemisphere(s string) (string, float64, byte) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return s, 1, 0
+	}
+
+	for _, candidate := range []string{s[len(s)-1:], s[:1]} {
+		switch hemisphere := strings.ToUpper(candidate)[0]; hemisphere {
+		case 'N', 'S', 'E', 'W':
+			if candidate == s[len(s)-1:] {
+				s = s[:len(s)-1]
+			} else {
+				s = s[1:]
+			}
+			sign := 1.0
+			if hemisphere == 'S' || hemisphere == 'W' {
+				sign = -1
+			}
+			return strings.TrimSpace(s), sign, hemisphere
+		}
+	}
+	return s, 1, 0
+}
+
+// angleFields will split up an angle into the numeric fields, treating the
+// usual degree, minute and second markers as whitespace.
+func angleFields(s string) []string {
+	return strings.Fields(strings.NewReplacer(
+		"°", " ", "'", " ", "\"", " ", "′", " ", "″", " ",
+	).Replace(s))
+}
+
+// ParseDMM will parse an angle in the "degrees and decimal minutes" format
+// often used by marine GPS units, such as "38 53.7083 N" or "-77 02.1936".
+//
+// The hemisphere may be given as a leading or trailing N, S, E or W (where
+// S and W are negative), or as a sign on the degrees, but not both. The
+// usual degree and minute markers ("38°53.7083'N") are also accepted.
+func ParseDMM(s string) (Degrees, error) {
+	rest, sign, hemisphere := splitHemisphere(s)
+
+	fields := angleFields(rest)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("geo.ParseDMM: expected degrees and minutes in %q", s)
+	}
+
+	if strings.HasPrefix(fields[0], "-") {
+		if hemisphere != 0 {
+			return 0, fmt.Errorf("geo.ParseDMM: both a sign and a hemisphere given in %q", s)
+		}
+		sign = -1
+	}
+
+	degrees, err := strconv.ParseUint(strings.TrimLeft(fields[0], "+-"), 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("geo.ParseDMM: invalid degrees in %q: %w", s, err)
+	}
+
+	minutes, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("geo.ParseDMM: invalid minutes in %q: %w", s, err)
+	}
+	if minutes < 0 || minutes >= 60 {
+		return 0, fmt.Errorf("geo.ParseDMM: minutes out of range in %q", s)
+	}
+
+	return Degrees(sign * (float64(degrees) + minutes/60)), nil
+}
+
+// dmm will return the whole degrees and decimal minutes of the absolute
+// value of the angle, with the minutes rounded to 4 decimal places (about
+// 20cm of Latitude), and if the angle is negative. The sign is worked out
+// after rounding, so tiny negative angles that round to 0 aren't negative.
+func (d Degrees) dmm() (int, float64, bool) {
+	var (
+		abs     = math.Abs(d.F64())
+		degrees = math.Floor(abs)
+		minutes = math.Round((abs-degrees)*60*1e4) / 1e4
+	)
+
+	if minutes >= 60 {
+		degrees++
+		minutes = 0
+	}
+	return int(degrees), minutes, d < 0 && (degrees != 0 || minutes != 0)
+}
+
+// DMM will return the angle in the "degrees and decimal minutes" format,
+// such as "38 53.7083", or "-77 02.1936" for negative angles. Since an angle
+// on its own doesn't know if it's a Latitude or a Longitude, this uses a sign
+// rather than a hemisphere letter; LLA.DMM will use hemisphere letters.
+//
+// The output of DMM can be parsed by ParseDMM.
+func (d Degrees) DMM() string {
+	degrees, minutes, negative := d.dmm()
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	return fmt.Sprintf("%s%d %07.4f", sign, degrees, minutes)
+}
+
+// DMM will return the Latitude and Longitude in the "degrees and decimal
+// minutes" format with hemisphere letters, such as "38 53.7083 N, 77 02.1936
+// W". The Altitude is not included.
+func (l LLA) DMM() string {
+	var (
+		latDegrees, latMinutes, latNegative = l.Latitude.dmm()
+		lonDegrees, lonMinutes, lonNegative = l.Longitude.dmm()
+
+		latHemisphere = "N"
+		lonHemisphere = "E"
+	)
+
+	if latNegative {
+		latHemisphere = "S"
+	}
+	if lonNegative {
+		lonHemisphere = "W"
+	}
+
+	return fmt.Sprintf(
+		"%d %07.4f %s, %d %07.4f %s",
+		latDegrees, latMinutes, latHemisphere,
+		lonDegrees, lonMinutes, lonHemisphere,
+	)
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,65 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDMM(t *testing.T) {
+	for input, expected := range map[string]float64{
+		"38 53.7083 N":   38.895138333,
+		"38 53.7083":     38.895138333,
+		"38°53.7083'N":   38.895138333,
+		"N 38 53.7083":   38.895138333,
+		"77 02.1936 W":   -77.036560,
+		"077 02.1936 w":  -77.036560,
+		"-77 02.1936":    -77.036560,
+		"0 30.0000 S":    -0.5,
+		"+122 30.0000 E": 122.5,
+	} {
+		degrees, err := geo.ParseDMM(input)
+		assert.NoError(t, err, input)
+		assert.InDelta(t, expected, degrees.F64(), 1e-8, input)
+	}
+}
+
+func TestParseDMMInvalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"38.5",
+		"38 53 42",
+		"-38 53.7083 S",
+		"38 60.0 N",
+		"38.5 10.0",
+		"abc 10.0",
+	} {
+		_, err := geo.ParseDMM(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestDMMFormat(t *testing.T) {
+	degrees, err := geo.ParseDMM("38 53.7083 N")
+	assert.NoError(t, err)
+	assert.Equal(t, "38 53.7083", degrees.DMM())
+
+	assert.Equal(t, "-77 02.1936", geo.Degrees(-77.036560).DMM())
+	assert.Equal(t, "-0 30.0000", geo.Degrees(-0.5).DMM())
+	assert.Equal(t, "39 00.0000", geo.Degrees(38.9999999).DMM())
+
+	// Tiny negative angles round to 0, which isn't negative.
+	assert.Equal(t, "0 00.0000", geo.Degrees(-1e-7).DMM())
+	assert.Equal(t, "0 00.0000 N, 0 00.0000 E", geo.LLA{Latitude: -1e-7, Longitude: -1e-7}.DMM())
+
+	lla := geo.LLA{Latitude: 38.895138333, Longitude: -77.036560}
+	assert.Equal(t, "38 53.7083 N, 77 02.1936 W", lla.DMM())
+
+	for _, value := range []geo.Degrees{38.895138333, -77.036560, -0.5, 0} {
+		parsed, err := geo.ParseDMM(value.DMM())
+		assert.NoError(t, err)
+		assert.InDelta(t, value.F64(), parsed.F64(), 1e-5)
+	}
+}
@@ -0,0 +1,86 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// j2000 is the J2000.0 epoch, which is to say, noon on January 1st, 2000.
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// GMST will return the Greenwich Mean Sidereal Time at the provided time, as
+// the angle the Earth has rotated (about the Z axis) under the inertial
+// frame, within [0, 2π).
+//
+// This uses the IAU 1982 polynomial, and takes the time provided to be UT1.
+// UTC is always within a second of UT1, which is usually close enough, but
+// precision work should correct for the difference before calling GMST.
+func GMST(t time.Time) Radians {
+	var (
+		d = float64(t.Sub(j2000)) / float64(24*time.Hour)
+		c = d / 36525
+
+		degrees = 280.46061837 + 360.98564736629*d + 0.000387933*c*c - c*c*c/38710000
+	)
+
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return Degrees(degrees).Radians()
+}
+
+// rotateZ will rotate the XYZ about the Z axis by the provided angle.
+func rotateZ(x XYZ, angle Radians) XYZ {
+	var (
+		sin = math.Sin(angle.F64())
+		cos = math.Cos(angle.F64())
+	)
+
+	return XYZ{
+		X: Meters(cos*x.X.F64() - sin*x.Y.F64()),
+		Y: Meters(sin*x.X.F64() + cos*x.Y.F64()),
+		Z: x.Z,
+	}
+}
+
+// ECIToECEF will convert a point in the Earth-Centered Inertial frame (as
+// used by most satellite propagators) into the Earth-Centered Earth-Fixed
+// frame (that the XYZ in this package is), at the provided time.
+//
+// The two frames share a Z axis, and differ only by the rotation of the Earth
+// under the inertial frame, as given by the Greenwich Mean Sidereal Time.
+// Precession, nutation and polar motion are not accounted for, so this should
+// be taken to be good to within a few hundred meters at orbital distances.
+func ECIToECEF(eci XYZ, t time.Time) XYZ {
+	return rotateZ(eci, -GMST(t))
+}
+
+// ECEFToECI will convert a point in the Earth-Centered Earth-Fixed frame into
+// the Earth-Centered Inertial frame at the provided time. This is the inverse
+// of ECIToECEF, and has all the same caveats.
+func ECEFToECI(ecef XYZ, t time.Time) XYZ {
+	return rotateZ(ecef, GMST(t))
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,72 @@
+package geo_test
+
+import (
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestECIToECEFAtZeroGMST(t *testing.T) {
+	// At J2000.0 GMST is 280.46061837°, and it advances 360.98564736629° per
+	// day, so this is the first time after J2000.0 that GMST is 0.
+	var (
+		j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+		days  = (360 - 280.46061837) / 360.98564736629
+		epoch = j2000.Add(time.Duration(days * float64(24*time.Hour)))
+
+		eci = geo.XYZ{X: 7000000, Y: -1000000, Z: 500000}
+	)
+
+	ecef := geo.ECIToECEF(eci, epoch)
+	assert.InDelta(t, eci.X.F64(), ecef.X.F64(), 1e-2)
+	assert.InDelta(t, eci.Y.F64(), ecef.Y.F64(), 1e-2)
+	assert.InDelta(t, eci.Z.F64(), ecef.Z.F64(), 1e-2)
+}
+
+func TestECIToECEFRoundTrip(t *testing.T) {
+	var (
+		when = time.Date(2021, time.March, 14, 15, 9, 26, 0, time.UTC)
+		eci  = geo.XYZ{X: 7000000, Y: -1000000, Z: 500000}
+	)
+
+	ecef := geo.ECIToECEF(eci, when)
+	assert.InDelta(t, eci.Z.F64(), ecef.Z.F64(), 1e-9)
+	assert.NotEqual(t, eci.X, ecef.X)
+
+	eci1 := geo.ECEFToECI(ecef, when)
+	assert.InEpsilon(t, eci.X.F64(), eci1.X.F64(), 1e-12)
+	assert.InEpsilon(t, eci.Y.F64(), eci1.Y.F64(), 1e-12)
+	assert.InEpsilon(t, eci.Z.F64(), eci1.Z.F64(), 1e-12)
+}
+
+func TestECIToECEFSiderealDay(t *testing.T) {
+	// After one sidereal day, the Earth has done one full rotation.
+	var (
+		when     = time.Date(2021, time.March, 14, 15, 9, 26, 0, time.UTC)
+		sidereal = time.Duration(86164.0905 * float64(time.Second))
+		eci      = geo.XYZ{X: 7000000, Y: -1000000, Z: 500000}
+	)
+
+	a := geo.ECIToECEF(eci, when)
+	b := geo.ECIToECEF(eci, when.Add(sidereal))
+	assert.InDelta(t, a.X.F64(), b.X.F64(), 1)
+	assert.InDelta(t, a.Y.F64(), b.Y.F64(), 1)
+}
+
+func TestGMST(t *testing.T) {
+	// Vallado, "Fundamentals of Astrodynamics and Applications", Example 3-5.
+	when := time.Date(1992, time.August, 20, 12, 14, 0, 0, time.UTC)
+	assert.InDelta(t, 152.578787886, geo.GMST(when).Degrees().F64(), 1.0/3600)
+
+	// At J2000.0
+	when = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+	assert.InDelta(t, 280.46061837, geo.GMST(when).Degrees().F64(), 1.0/3600)
+
+	// And something well before J2000.0, so that the angle needs wrapping.
+	when = time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC)
+	gmst := geo.GMST(when).Degrees().F64()
+	assert.True(t, gmst >= 0 && gmst < 360)
+}
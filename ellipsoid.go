@@ -0,0 +1,69 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// Ellipsoid is an oblate ellipsoid of revolution, described by the radius at
+// the Equator (the SemiMajorAxis) and at the poles (the SemiMinorAxis).
+type Ellipsoid struct {
+	SemiMajorAxis Meters
+	SemiMinorAxis Meters
+}
+
+// WGS84Ellipsoid will return the Ellipsoid used by the WGS84
+// CoordinateSystem.
+func WGS84Ellipsoid() Ellipsoid {
+	return Ellipsoid{
+		SemiMajorAxis: Meters(wgs84A),
+		SemiMinorAxis: Meters(wgs84B),
+	}
+}
+
+// Flattening will return the flattening of the Ellipsoid, (a - b) / a.
+func (e Ellipsoid) Flattening() float64 {
+	return (e.SemiMajorAxis - e.SemiMinorAxis).F64() / e.SemiMajorAxis.F64()
+}
+
+// EccentricitySquared will return the square of the first eccentricity of
+// the Ellipsoid.
+func (e Ellipsoid) EccentricitySquared() float64 {
+	f := e.Flattening()
+	return f * (2 - f)
+}
+
+// ParallelRadius will return the radius of the circle of Latitude (the
+// parallel) on the surface of the Ellipsoid, which is to say, the distance
+// from the surface to the axis of rotation. This is the prime vertical
+// radius of curvature scaled by cos(Latitude), and is what an East / West
+// distance along the parallel needs to be scaled by.
+func (e Ellipsoid) ParallelRadius(lat Degrees) Meters {
+	var (
+		phi    = lat.Radians().F64()
+		sinPhi = math.Sin(phi)
+		n      = e.SemiMajorAxis.F64() / math.Sqrt(1-e.EccentricitySquared()*sinPhi*sinPhi)
+	)
+	return Meters(math.Max(0, n*math.Cos(phi)))
+}
+
+// vim: foldmethod=marker
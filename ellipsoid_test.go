@@ -0,0 +1,31 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEllipsoidParallelRadius(t *testing.T) {
+	wgs := geo.WGS84Ellipsoid()
+
+	assert.InEpsilon(t, 6378137.0, wgs.ParallelRadius(0).F64(), 1e-12)
+	assert.InDelta(t, 0, wgs.ParallelRadius(90).F64(), 1e-6)
+	assert.InDelta(t, 0, wgs.ParallelRadius(-90).F64(), 1e-6)
+	assert.InEpsilon(t, wgs.ParallelRadius(45).F64(), wgs.ParallelRadius(-45).F64(), 1e-12)
+
+	// This should line up with the distance from the Z axis of a point on
+	// the surface.
+	xyz := geo.WGS84().LLAToXYZ(geo.LLA{Latitude: 38.897957, Longitude: -77.036560})
+	assert.InEpsilon(t,
+		math.Hypot(xyz.X.F64(), xyz.Y.F64()),
+		wgs.ParallelRadius(38.897957).F64(),
+		1e-9,
+	)
+
+	sphere := geo.Ellipsoid{SemiMajorAxis: 1000, SemiMinorAxis: 1000}
+	assert.InEpsilon(t, 500, sphere.ParallelRadius(60).F64(), 1e-12)
+}
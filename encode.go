@@ -0,0 +1,67 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+var (
+	// encodeLatitudeStep is the size (in Degrees) of one step of the
+	// encoded Latitude, which spans [-90, 90] end to end.
+	encodeLatitudeStep = 180 / float64(math.MaxUint32)
+
+	// encodeLongitudeStep is the size (in Degrees) of one step of the
+	// encoded Longitude, which wraps around [-180, 180).
+	encodeLongitudeStep = 360 / float64(1<<32)
+)
+
+// Encode will pack the Latitude and Longitude of the LLA into a single
+// uint64, as fixed-point values -- the Latitude in the upper 32 bits, and the
+// Longitude in the lower 32. This is a quarter of the size of the three
+// float64s in an LLA, which adds up when storing millions of points.
+//
+// Each step of the Latitude is about 4.2e-8 Degrees, and each step of the
+// Longitude about 8.4e-8 Degrees, so a decoded point is within about half a
+// centimeter of the original, anywhere on the Earth. The Altitude is dropped
+// entirely, Latitudes outside of [-90, 90] are clamped, and the Longitude is
+// wrapped to be within [-180, 180).
+func (l LLA) Encode() uint64 {
+	var (
+		lat = math.Max(-90, math.Min(90, l.Latitude.F64()))
+		lon = normalizeLongitude(l.Longitude).F64()
+
+		latCode = uint64(math.Round((lat + 90) / encodeLatitudeStep))
+		lonCode = uint64(math.Round((lon+180)/encodeLongitudeStep)) & math.MaxUint32
+	)
+	return latCode<<32 | lonCode
+}
+
+// DecodeLLA will unpack an LLA from the uint64 returned by LLA.Encode. The
+// Altitude isn't encoded, and is always 0.
+func DecodeLLA(code uint64) LLA {
+	return LLA{
+		Latitude:  Degrees(float64(code>>32)*encodeLatitudeStep - 90),
+		Longitude: Degrees(float64(code&math.MaxUint32)*encodeLongitudeStep - 180),
+	}
+}
+
+// vim: foldmethod=marker
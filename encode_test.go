@@ -0,0 +1,49 @@
+package geo_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	points := []geo.LLA{
+		{Latitude: 90, Longitude: 0},
+		{Latitude: -90, Longitude: 0},
+		{Latitude: 0, Longitude: -180},
+		{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30},
+	}
+	for i := 0; i < 1000; i++ {
+		points = append(points, geo.RandomLLA(r))
+	}
+
+	for _, point := range points {
+		decoded := geo.DecodeLLA(point.Encode())
+		assert.Equal(t, geo.Meters(0), decoded.Altitude)
+
+		point.Altitude = 0
+		d, err := geo.HaversineDistance(point, decoded)
+		assert.NoError(t, err)
+		assert.Less(t, d.F64(), 0.005)
+	}
+}
+
+func TestEncodeWrap(t *testing.T) {
+	assert.Equal(t,
+		geo.LLA{Latitude: 10, Longitude: 170}.Encode(),
+		geo.LLA{Latitude: 10, Longitude: -190}.Encode(),
+	)
+	assert.Equal(t,
+		geo.LLA{Latitude: 90}.Encode(),
+		geo.LLA{Latitude: 95}.Encode(),
+	)
+
+	// Just shy of 180 rounds up, and wraps around to -180.
+	decoded := geo.DecodeLLA(geo.LLA{Longitude: 179.99999999}.Encode())
+	assert.InDelta(t, -180, decoded.Longitude.F64(), 1e-7)
+}
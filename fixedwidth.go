@@ -0,0 +1,111 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Column is the span of bytes a field takes up on each line of a fixed-width
+// text file, from Start (inclusive) to End (exclusive), counting from 0.
+type Column struct {
+	Start int
+	End   int
+}
+
+// present will return true if the Column has been set at all.
+func (c Column) present() bool {
+	return c.End > 0
+}
+
+// parse will pull the Column out of the line, and parse it as a float64.
+func (c Column) parse(line string) (float64, error) {
+	if c.Start < 0 || c.End < c.Start || c.End > len(line) {
+		return 0, fmt.Errorf("columns %d-%d are past the end of the line", c.Start, c.End)
+	}
+	return strconv.ParseFloat(strings.TrimSpace(line[c.Start:c.End]), 64)
+}
+
+// ColumnSpec describes where the Latitude, Longitude and (optionally)
+// Altitude fields are on each line of a fixed-width text file, in decimal
+// Degrees and Meters. If the file has no Altitude, leave the Altitude Column
+// unset (with an End of 0), and the Altitude will be 0.
+type ColumnSpec struct {
+	Latitude  Column
+	Longitude Column
+	Altitude  Column
+}
+
+// ReadFixedWidth will read every line of the fixed-width text file, and parse
+// out an LLA from the Columns given by the ColumnSpec. Blank lines are
+// skipped, and anything outside of the Columns is ignored.
+//
+// If any line is too short to hold the Columns, or a field can't be parsed,
+// an error giving the line number is returned.
+func ReadFixedWidth(r io.Reader, spec ColumnSpec) ([]LLA, error) {
+	var (
+		scanner = bufio.NewScanner(r)
+		points  = []LLA{}
+		lineNo  = 0
+	)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		latitude, err := spec.Latitude.parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("geo.ReadFixedWidth: line %d: Latitude: %w", lineNo, err)
+		}
+		longitude, err := spec.Longitude.parse(line)
+		if err != nil {
+			return nil, fmt.Errorf("geo.ReadFixedWidth: line %d: Longitude: %w", lineNo, err)
+		}
+
+		var altitude float64
+		if spec.Altitude.present() {
+			altitude, err = spec.Altitude.parse(line)
+			if err != nil {
+				return nil, fmt.Errorf("geo.ReadFixedWidth: line %d: Altitude: %w", lineNo, err)
+			}
+		}
+
+		points = append(points, LLA{
+			Latitude:  Degrees(latitude),
+			Longitude: Degrees(longitude),
+			Altitude:  Meters(altitude),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("geo.ReadFixedWidth: %w", err)
+	}
+	return points, nil
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,54 @@
+package geo_test
+
+import (
+	"strings"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFixedWidth(t *testing.T) {
+	data := strings.Join([]string{
+		"STA1  38.897957 -77.036560   30.5",
+		"STA2  51.510357  -0.116773    5.0",
+		"",
+		"STA3 -33.868800 151.209300 1200.0",
+	}, "\n")
+
+	spec := geo.ColumnSpec{
+		Latitude:  geo.Column{Start: 5, End: 15},
+		Longitude: geo.Column{Start: 15, End: 26},
+		Altitude:  geo.Column{Start: 26, End: 33},
+	}
+
+	points, err := geo.ReadFixedWidth(strings.NewReader(data), spec)
+	assert.NoError(t, err)
+	assert.Equal(t, []geo.LLA{
+		{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30.5},
+		{Latitude: 51.510357, Longitude: -0.116773, Altitude: 5},
+		{Latitude: -33.8688, Longitude: 151.2093, Altitude: 1200},
+	}, points)
+
+	// Without the Altitude Column, the Altitude is left at 0.
+	spec.Altitude = geo.Column{}
+	points, err = geo.ReadFixedWidth(strings.NewReader(data), spec)
+	assert.NoError(t, err)
+	assert.Len(t, points, 3)
+	assert.Equal(t, geo.LLA{Latitude: 38.897957, Longitude: -77.036560}, points[0])
+}
+
+func TestReadFixedWidthErrors(t *testing.T) {
+	spec := geo.ColumnSpec{
+		Latitude:  geo.Column{Start: 0, End: 10},
+		Longitude: geo.Column{Start: 10, End: 20},
+	}
+
+	_, err := geo.ReadFixedWidth(strings.NewReader("38.897957 -77.036560\n38.8979"), spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	_, err = geo.ReadFixedWidth(strings.NewReader("38.897957 -77.0365X0"), spec)
+	assert.Error(t, err)
+}
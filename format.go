@@ -0,0 +1,102 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formatDirective will rebuild the formatting directive (such as "%-8.2f")
+// that fmt was handed, so that it can be passed along to fmt as-is.
+func formatDirective(f fmt.State, verb rune) string {
+	var directive strings.Builder
+	directive.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			directive.WriteRune(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		directive.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := f.Precision(); ok {
+		directive.WriteByte('.')
+		directive.WriteString(strconv.Itoa(precision))
+	}
+	directive.WriteRune(verb)
+	return directive.String()
+}
+
+// formatWithUnit will write out the value for fmt. The %v and %s verbs write
+// the value (with the precision, if one was given) followed by the unit,
+// padded out to the width, and every other verb is handled by fmt just like
+// a plain float64. That includes %#v, since the Go syntax for the value is
+// the bare number.
+func formatWithUnit(f fmt.State, verb rune, value float64, unit string) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, formatDirective(f, verb), value)
+		return
+	case verb == 'v', verb == 's':
+	default:
+		fmt.Fprintf(f, formatDirective(f, verb), value)
+		return
+	}
+
+	number := strconv.FormatFloat(value, 'g', -1, 64)
+	if precision, ok := f.Precision(); ok {
+		number = strconv.FormatFloat(value, 'f', precision, 64)
+	}
+	if f.Flag('+') && value >= 0 {
+		number = "+" + number
+	}
+
+	text := number + unit
+	if width, ok := f.Width(); ok && width > len([]rune(text)) {
+		padding := strings.Repeat(" ", width-len([]rune(text)))
+		if f.Flag('-') {
+			text += padding
+		} else {
+			text = padding + text
+		}
+	}
+	fmt.Fprint(f, text)
+}
+
+// Format will write out the Meters for the fmt package, implementing
+// fmt.Formatter. The %v and %s verbs include the unit, so 1234.5 Meters is
+// written as "1234.5m", and every other verb (such as %f or %g) writes the
+// bare number, just like a float64.
+func (m Meters) Format(f fmt.State, verb rune) {
+	formatWithUnit(f, verb, m.F64(), "m")
+}
+
+// Format will write out the Degrees for the fmt package, implementing
+// fmt.Formatter. The %v and %s verbs include the unit, so 38.9 Degrees is
+// written as "38.9°", and every other verb (such as %f or %g) writes the
+// bare number, just like a float64.
+func (d Degrees) Format(f fmt.State, verb rune) {
+	formatWithUnit(f, verb, d.F64(), "°")
+}
+
+// vim: foldmethod=marker
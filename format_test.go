@@ -0,0 +1,46 @@
+package geo_test
+
+import (
+	"fmt"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetersFormat(t *testing.T) {
+	m := geo.Meters(1234.5)
+
+	assert.Equal(t, "1234.5m", fmt.Sprintf("%v", m))
+	assert.Equal(t, "1234.5m", fmt.Sprintf("%s", m))
+	assert.Equal(t, "1234.50m", fmt.Sprintf("%.2v", m))
+	assert.Equal(t, "  1234.5m", fmt.Sprintf("%9v", m))
+	assert.Equal(t, "1234.5m  |", fmt.Sprintf("%-9v|", m))
+	assert.Equal(t, "+1234.5m", fmt.Sprintf("%+v", m))
+
+	// Everything else is just a float64.
+	assert.Equal(t, "1234.500000", fmt.Sprintf("%f", m))
+	assert.Equal(t, "1234.5", fmt.Sprintf("%.1f", m))
+	assert.Equal(t, fmt.Sprintf("%08.2f", 1234.5), fmt.Sprintf("%08.2f", m))
+	assert.Equal(t, fmt.Sprintf("%e", 1234.5), fmt.Sprintf("%e", m))
+	assert.Equal(t, fmt.Sprintf("%#v", 1234.5), fmt.Sprintf("%#v", m))
+}
+
+func TestDegreesFormat(t *testing.T) {
+	d := geo.Degrees(38.9)
+
+	assert.Equal(t, "38.9°", fmt.Sprintf("%v", d))
+	assert.Equal(t, "38.90°", fmt.Sprintf("%.2s", d))
+	assert.Equal(t, "-77.04°", fmt.Sprintf("%.2v", geo.Degrees(-77.0366)))
+	assert.Equal(t, "  38.9°", fmt.Sprintf("%7v", d))
+	assert.Equal(t, "38.900000", fmt.Sprintf("%f", d))
+
+	assert.Equal(t, fmt.Sprintf("%#v", 38.9), fmt.Sprintf("%#v", d))
+
+	assert.Equal(t, "{38.9° -77.1° 30m}", fmt.Sprintf("%v", geo.LLA{Latitude: 38.9, Longitude: -77.1, Altitude: 30}))
+	assert.Equal(t,
+		"geo.LLA{Latitude:38.9, Longitude:-77.1, Altitude:30}",
+		fmt.Sprintf("%#v", geo.LLA{Latitude: 38.9, Longitude: -77.1, Altitude: 30}),
+	)
+}
@@ -0,0 +1,133 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// LocalFrame is a flat cartesian frame tangent to the Earth at a reference
+// point, like ENU, but with the horizontal axes rotated to line up with
+// something more useful locally, such as a runway or a city street grid.
+type LocalFrame interface {
+	// ToLocal will return the position of the LLA in the frame.
+	ToLocal(LLA) (x, y, z Meters)
+
+	// FromLocal will return the LLA of the position in the frame.
+	FromLocal(x, y, z Meters) LLA
+}
+
+// NewLocalFrame will return a LocalFrame tangent to the WGS84 ellipsoid at
+// the reference point, with the Y axis pointing along the rotation (in
+// Degrees clockwise from North), the X axis 90 degrees clockwise from that,
+// and Z pointing Up.
+//
+// A rotation of 0 is the same thing as ENU, where X is East and Y is North.
+// A rotation of 90 will have Y pointing East, and X pointing South.
+func NewLocalFrame(ref LLA, rotation Degrees) LocalFrame {
+	return localFrame{
+		cs:  WGS84(),
+		ref: ref,
+		sin: math.Sin(rotation.Radians().F64()),
+		cos: math.Cos(rotation.Radians().F64()),
+	}
+}
+
+type localFrame struct {
+	cs       CoordinateSystem
+	ref      LLA
+	sin, cos float64
+}
+
+func (f localFrame) ToLocal(lla LLA) (Meters, Meters, Meters) {
+	var (
+		enu = f.cs.LLAToENU(f.ref, lla)
+		e   = enu.East.F64()
+		n   = enu.North.F64()
+	)
+	return Meters(e*f.cos - n*f.sin), Meters(e*f.sin + n*f.cos), enu.Up
+}
+
+func (f localFrame) FromLocal(x, y, z Meters) LLA {
+	return OffsetLLA(f.cs, f.ref, ENU{
+		East:  Meters(x.F64()*f.cos + y.F64()*f.sin),
+		North: Meters(y.F64()*f.cos - x.F64()*f.sin),
+		Up:    z,
+	})
+}
+
+// RotateAbout will return a copy of the points rotated clockwise (as seen
+// from above) by the angle around the pivot, in the WGS84 tangent plane at
+// the pivot. A point due North of the pivot rotated by 90 Degrees will end
+// up due East of it, the same distance away.
+//
+// The height of each point above the tangent plane is kept as is, so points
+// near the pivot will keep their Altitude. Like any tangent plane math, this
+// gets less accurate the further the points are from the pivot.
+func RotateAbout(points []LLA, pivot LLA, angle Degrees) []LLA {
+	var (
+		wgs = WGS84()
+		sin = math.Sin(angle.Radians().F64())
+		cos = math.Cos(angle.Radians().F64())
+
+		rotated = make([]LLA, len(points))
+	)
+
+	for i, point := range points {
+		var (
+			enu = wgs.LLAToENU(pivot, point)
+			e   = enu.East.F64()
+			n   = enu.North.F64()
+		)
+		rotated[i] = OffsetLLA(wgs, pivot, ENU{
+			East:  Meters(e*cos + n*sin),
+			North: Meters(n*cos - e*sin),
+			Up:    enu.Up,
+		})
+	}
+	return rotated
+}
+
+// Translate will return a copy of the points with each one moved by the
+// offset, where the offset is in the tangent plane (in the provided
+// CoordinateSystem) at the first point. Every point is moved by the very same
+// displacement in that plane, so the shape as a whole moves without being
+// bent by the curve of the Earth -- which means that, like RotateAbout, this
+// is best used on shapes that are small compared to the Earth.
+func Translate(cs CoordinateSystem, points []LLA, offset ENU) []LLA {
+	translated := make([]LLA, len(points))
+	if len(points) == 0 {
+		return translated
+	}
+
+	ref := points[0]
+	for i, point := range points {
+		enu := cs.LLAToENU(ref, point)
+		translated[i] = OffsetLLA(cs, ref, ENU{
+			East:  enu.East + offset.East,
+			North: enu.North + offset.North,
+			Up:    enu.Up + offset.Up,
+		})
+	}
+	return translated
+}
+
+// vim: foldmethod=marker
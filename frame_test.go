@@ -0,0 +1,104 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalFrameUnrotated(t *testing.T) {
+	var (
+		ref   = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 20}
+		point = geo.LLA{Latitude: 38.9, Longitude: -77.03, Altitude: 50}
+		frame = geo.NewLocalFrame(ref, 0)
+		enu   = geo.WGS84().LLAToENU(ref, point)
+	)
+
+	x, y, z := frame.ToLocal(point)
+	assert.InDelta(t, enu.East.F64(), x.F64(), 1e-9)
+	assert.InDelta(t, enu.North.F64(), y.F64(), 1e-9)
+	assert.InDelta(t, enu.Up.F64(), z.F64(), 1e-9)
+}
+
+func TestLocalFrameRotated(t *testing.T) {
+	var (
+		ref   = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		frame = geo.NewLocalFrame(ref, 90)
+		wgs   = geo.WGS84()
+	)
+
+	// Due East is straight up the Y axis.
+	x, y, z := frame.ToLocal(geo.OffsetLLA(wgs, ref, geo.ENU{East: 100}))
+	assert.InDelta(t, 0, x.F64(), 1e-6)
+	assert.InDelta(t, 100, y.F64(), 1e-6)
+	assert.InDelta(t, 0, z.F64(), 1e-6)
+
+	// Due North is off to the left, along -X.
+	x, y, _ = frame.ToLocal(geo.OffsetLLA(wgs, ref, geo.ENU{North: 100}))
+	assert.InDelta(t, -100, x.F64(), 1e-6)
+	assert.InDelta(t, 0, y.F64(), 1e-6)
+
+	lla := frame.FromLocal(30, 40, 5)
+	enu := wgs.LLAToENU(ref, lla)
+	assert.InDelta(t, -30, enu.North.F64(), 1e-6)
+	assert.InDelta(t, 40, enu.East.F64(), 1e-6)
+	assert.InDelta(t, 5, enu.Up.F64(), 1e-6)
+
+	x, y, z = frame.ToLocal(lla)
+	assert.InDelta(t, 30, x.F64(), 1e-6)
+	assert.InDelta(t, 40, y.F64(), 1e-6)
+	assert.InDelta(t, 5, z.F64(), 1e-6)
+}
+
+func TestRotateAbout(t *testing.T) {
+	var (
+		wgs   = geo.WGS84()
+		pivot = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		north = geo.OffsetLLA(wgs, pivot, geo.ENU{North: 1000})
+		east  = geo.OffsetLLA(wgs, pivot, geo.ENU{East: 500, North: 500})
+	)
+
+	rotated := geo.RotateAbout([]geo.LLA{north, east, pivot}, pivot, 90)
+	assert.Len(t, rotated, 3)
+
+	enu := wgs.LLAToENU(pivot, rotated[0])
+	assert.InDelta(t, 1000, enu.East.F64(), 1e-6)
+	assert.InDelta(t, 0, enu.North.F64(), 1e-6)
+	assert.InDelta(t, wgs.LLAToENU(pivot, north).Up.F64(), enu.Up.F64(), 1e-6)
+
+	enu = wgs.LLAToENU(pivot, rotated[1])
+	assert.InDelta(t, 500, enu.East.F64(), 1e-6)
+	assert.InDelta(t, -500, enu.North.F64(), 1e-6)
+
+	enu = wgs.LLAToENU(pivot, rotated[2])
+	assert.InDelta(t, 0, enu.Distance().F64(), 1e-6)
+
+	enu = wgs.LLAToENU(pivot, geo.RotateAbout([]geo.LLA{north}, pivot, -90)[0])
+	assert.InDelta(t, -1000, enu.East.F64(), 1e-6)
+	assert.InDelta(t, 0, enu.North.F64(), 1e-6)
+}
+
+func TestTranslate(t *testing.T) {
+	var (
+		wgs    = geo.WGS84()
+		points = []geo.LLA{
+			{Latitude: 38.897957, Longitude: -77.036560},
+			{Latitude: 38.889931, Longitude: -77.009003},
+			{Latitude: 38.881000, Longitude: -77.030000, Altitude: 10},
+		}
+	)
+
+	translated := geo.Translate(wgs, points, geo.ENU{East: 100})
+	assert.Len(t, translated, len(points))
+
+	for i, point := range points {
+		enu := wgs.LLAToENU(point, translated[i])
+		assert.InDelta(t, 100, enu.East.F64(), 0.1)
+		assert.InDelta(t, 0, enu.North.F64(), 0.1)
+		assert.InDelta(t, 0, enu.Up.F64(), 0.1)
+	}
+
+	assert.Empty(t, geo.Translate(wgs, nil, geo.ENU{East: 100}))
+}
@@ -0,0 +1,188 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// geodesicIterations is the most iterations of Vincenty's method
+// Ellipsoid.GeodesicInverse will run before giving up.
+const geodesicIterations = 200
+
+// geodesicSteps is the number of (Simpson's rule) steps used to integrate
+// along the auxiliary sphere. The integrands are smooth, so this is plenty
+// to get well under a millimeter.
+const geodesicSteps = 512
+
+// Geodesic is the solution to the inverse geodesic problem between two
+// points on an Ellipsoid -- the shortest path between them along the
+// surface -- along with the auxiliary quantities needed to work out how
+// errors at one end of the geodesic show up at the other.
+type Geodesic struct {
+	// Distance is the length of the geodesic.
+	Distance Meters
+
+	// InitialAzimuth is the bearing (in Degrees clockwise from North,
+	// within [0, 360)) of the geodesic as it leaves the first point.
+	InitialAzimuth Degrees
+
+	// FinalAzimuth is the bearing (in Degrees clockwise from North, within
+	// [0, 360)) of the geodesic as it arrives at the second point.
+	FinalAzimuth Degrees
+
+	// ReducedLength is the reduced length of the geodesic, m12. Turning the
+	// InitialAzimuth by a (small) angle in Radians will move the second
+	// point sideways by ReducedLength times that angle.
+	ReducedLength Meters
+
+	// GeodesicScale12 is the geodesic scale M12 -- how much two geodesics
+	// that start off parallel (and a small distance apart) at the first
+	// point have converged (or diverged) by the second point.
+	GeodesicScale12 float64
+
+	// GeodesicScale21 is the geodesic scale M21, which is GeodesicScale12,
+	// but from the second point to the first.
+	GeodesicScale21 float64
+}
+
+// integrate will integrate f from a to b using Simpson's rule.
+func integrate(f func(float64) float64, a, b float64) float64 {
+	var (
+		h   = (b - a) / geodesicSteps
+		sum = f(a) + f(b)
+	)
+	for i := 1; i < geodesicSteps; i++ {
+		weight := 2.0
+		if i%2 == 1 {
+			weight = 4
+		}
+		sum += weight * f(a+float64(i)*h)
+	}
+	return sum * h / 3
+}
+
+// GeodesicInverse will solve the inverse geodesic problem between a and b on
+// the Ellipsoid, returning the Distance and the azimuths at each end along
+// with the reduced length and geodesic scales (as described by Karney in
+// "Algorithms for geodesics", 2013), which are what's needed to propagate
+// variances along the geodesic in a network adjustment.
+//
+// The azimuths are found using Vincenty's method, and the lengths by
+// numerically integrating along the auxiliary sphere. Vincenty's method
+// fails to converge for points that are very nearly on opposite sides of the
+// Earth, in which case an error is returned.
+//
+// Just like HaversineDistance, this will return an error if either of the
+// provided geo.LLA structs have an Altitude other than 0.
+func (e Ellipsoid) GeodesicInverse(a, b LLA) (Geodesic, error) {
+	if a.Altitude != 0 || b.Altitude != 0 {
+		return Geodesic{}, fmt.Errorf("geo.Ellipsoid.GeodesicInverse: Altitude must be 0")
+	}
+
+	var (
+		f   = e.Flattening()
+		ep2 = e.EccentricitySquared() / (1 - e.EccentricitySquared())
+
+		u1 = math.Atan((1 - f) * math.Tan(a.Latitude.Radians().F64()))
+		u2 = math.Atan((1 - f) * math.Tan(b.Latitude.Radians().F64()))
+		l  = normalizeLongitude(b.Longitude - a.Longitude).Radians().F64()
+
+		sinU1, cosU1 = math.Sin(u1), math.Cos(u1)
+		sinU2, cosU2 = math.Sin(u2), math.Cos(u2)
+
+		lambda                  = l
+		sinLambda, cosLambda    float64
+		sigma, sinSigma, cosSig float64
+		cosSqAlpha, cos2SigmaM  float64
+		converged               bool
+	)
+
+	for i := 0; i < geodesicIterations; i++ {
+		sinLambda, cosLambda = math.Sin(lambda), math.Cos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			// The very same point.
+			return Geodesic{GeodesicScale12: 1, GeodesicScale21: 1}, nil
+		}
+		cosSig = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSig)
+
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// Off the Equator.
+			cos2SigmaM = cosSig - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		next := l + (1-c)*f*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSig*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(next-lambda) < 1e-12 {
+			lambda = next
+			converged = true
+			break
+		}
+		lambda = next
+	}
+
+	if !converged {
+		return Geodesic{}, fmt.Errorf("geo.Ellipsoid.GeodesicInverse: failed to converge")
+	}
+
+	sinLambda, cosLambda = math.Sin(lambda), math.Cos(lambda)
+
+	var (
+		alpha1 = math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		alpha2 = math.Atan2(cosU1*sinLambda, -sinU1*cosU2+cosU1*sinU2*cosLambda)
+
+		// sigma1 is the arc along the auxiliary sphere from where the
+		// geodesic crosses the Equator (heading North) to a.
+		sigma1 = math.Atan2(sinU1, math.Cos(alpha1)*cosU1)
+		sigma2 = sigma1 + sigma
+
+		k2 = ep2 * cosSqAlpha
+		w  = func(s float64) float64 { return math.Sqrt(1 + k2*math.Sin(s)*math.Sin(s)) }
+
+		distance = e.SemiMinorAxis.F64() * integrate(w, sigma1, sigma2)
+		j12      = integrate(func(s float64) float64 { return w(s) - 1/w(s) }, sigma1, sigma2)
+
+		ssig1, csig1 = math.Sin(sigma1), math.Cos(sigma1)
+		ssig2, csig2 = math.Sin(sigma2), math.Cos(sigma2)
+		dn1, dn2     = w(sigma1), w(sigma2)
+
+		m12b   = dn2*csig1*ssig2 - dn1*ssig1*csig2 - csig1*csig2*j12
+		csig12 = csig1*csig2 + ssig1*ssig2
+		t      = ep2 * (cosU1 - cosU2) * (cosU1 + cosU2) / (dn1 + dn2)
+	)
+
+	return Geodesic{
+		Distance:        Meters(distance),
+		InitialAzimuth:  normalizeBearing(Radians(alpha1).Degrees()),
+		FinalAzimuth:    normalizeBearing(Radians(alpha2).Degrees()),
+		ReducedLength:   Meters(e.SemiMinorAxis.F64() * m12b),
+		GeodesicScale12: csig12 + (t*ssig2-csig2*j12)*ssig1/dn1,
+		GeodesicScale21: csig12 - (t*ssig1-csig1*j12)*ssig2/dn2,
+	}, nil
+}
+
+// vim: foldmethod=marker
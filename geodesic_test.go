@@ -0,0 +1,111 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dms(d, m, s float64) geo.Degrees {
+	if d < 0 {
+		return geo.Degrees(d - m/60 - s/3600)
+	}
+	return geo.Degrees(d + m/60 + s/3600)
+}
+
+func TestGeodesicInverseVincenty(t *testing.T) {
+	// Vincenty's own worked example, Flinders Peak to Buninyong.
+	g, err := geo.WGS84Ellipsoid().GeodesicInverse(
+		geo.LLA{Latitude: dms(-37, 57, 3.72030), Longitude: dms(144, 25, 29.52440)},
+		geo.LLA{Latitude: dms(-37, 39, 10.15610), Longitude: dms(143, 55, 35.38390)},
+	)
+	assert.NoError(t, err)
+	assert.InDelta(t, 54972.271, g.Distance.F64(), 1e-3)
+	assert.InDelta(t, dms(306, 52, 5.37).F64(), g.InitialAzimuth.F64(), 0.01/3600)
+	assert.InDelta(t, dms(307, 10, 25.07).F64(), g.FinalAzimuth.F64(), 0.01/3600)
+}
+
+func TestGeodesicInverseGeodSolve(t *testing.T) {
+	// The JFK to Singapore Changi example from the GeodSolve documentation,
+	// to the precision it's printed at (0.1" and 1m).
+	g, err := geo.WGS84Ellipsoid().GeodesicInverse(
+		geo.LLA{Latitude: dms(40, 38, 23), Longitude: dms(-73, 46, 44)},
+		geo.LLA{Latitude: dms(1, 21, 33), Longitude: dms(103, 59, 22)},
+	)
+	assert.NoError(t, err)
+	assert.InDelta(t, 15347628, g.Distance.F64(), 0.5)
+	assert.InDelta(t, dms(3, 18, 29.9).F64(), g.InitialAzimuth.F64(), 0.05/3600)
+	assert.InDelta(t, dms(177, 29, 9.2).F64(), g.FinalAzimuth.F64(), 0.05/3600)
+}
+
+func TestGeodesicInverseMidLatitude(t *testing.T) {
+	// JFK to Heathrow. The expected values come from shooting along the
+	// geodesic with an RK4 integrator on the ellipsoid itself, rather than
+	// the auxiliary sphere that GeodesicInverse works on.
+	g, err := geo.WGS84Ellipsoid().GeodesicInverse(
+		geo.LLA{Latitude: 40.64, Longitude: -73.78},
+		geo.LLA{Latitude: 51.47, Longitude: -0.46},
+	)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 5554747.739656, g.Distance.F64(), 1e-9)
+	assert.InDelta(t, 51.3817515699, g.InitialAzimuth.F64(), 1e-8)
+	assert.InDelta(t, 107.9791490135, g.FinalAzimuth.F64(), 1e-8)
+}
+
+func TestGeodesicInverseSphere(t *testing.T) {
+	var (
+		sphere = geo.Ellipsoid{SemiMajorAxis: 6371000, SemiMinorAxis: 6371000}
+		a      = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		b      = geo.LLA{Latitude: 51.5007, Longitude: -0.1246}
+	)
+
+	g, err := sphere.GeodesicInverse(a, b)
+	assert.NoError(t, err)
+
+	sigma := g.Distance.F64() / 6371000
+	hd, err := geo.HaversineDistance(a, b)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, hd.F64(), g.Distance.F64(), 1e-9)
+	assert.InEpsilon(t, 6371000*math.Sin(sigma), g.ReducedLength.F64(), 1e-9)
+	assert.InEpsilon(t, math.Cos(sigma), g.GeodesicScale12, 1e-9)
+	assert.InEpsilon(t, math.Cos(sigma), g.GeodesicScale21, 1e-9)
+}
+
+func TestGeodesicInverseReducedLength(t *testing.T) {
+	var (
+		wgs = geo.WGS84Ellipsoid()
+		a   = geo.LLA{Latitude: 40.64, Longitude: -73.78}
+		b   = geo.LLA{Latitude: 51.47, Longitude: -0.46}
+	)
+
+	g, err := wgs.GeodesicInverse(a, b)
+	assert.NoError(t, err)
+
+	// Swapping the ends gives the same reduced length, with the scales
+	// swapped.
+	r, err := wgs.GeodesicInverse(b, a)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, g.Distance.F64(), r.Distance.F64(), 1e-12)
+	assert.InEpsilon(t, g.ReducedLength.F64(), r.ReducedLength.F64(), 1e-9)
+	assert.InEpsilon(t, g.GeodesicScale12, r.GeodesicScale21, 1e-9)
+	assert.InEpsilon(t, g.GeodesicScale21, r.GeodesicScale12, 1e-9)
+
+	// Moving b sideways (at a right angle to the geodesic) should turn the
+	// InitialAzimuth by that distance over the ReducedLength.
+	moved := geo.DestinationPoint(b, g.FinalAzimuth+90, 10)
+	m, err := wgs.GeodesicInverse(a, moved)
+	assert.NoError(t, err)
+	turn := (m.InitialAzimuth - g.InitialAzimuth).Radians().F64()
+	assert.InEpsilon(t, 10/g.ReducedLength.F64(), turn, 1e-3)
+
+	same, err := wgs.GeodesicInverse(a, a)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, same.Distance.F64())
+	assert.Equal(t, 1.0, same.GeodesicScale12)
+
+	_, err = wgs.GeodesicInverse(geo.LLA{Altitude: 10}, b)
+	assert.Error(t, err)
+}
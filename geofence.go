@@ -0,0 +1,57 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+// Geofence is an area on the surface, such as a Circle or a Polygon, that
+// can be checked for containing a point.
+type Geofence interface {
+	// Contains will return true if the point is inside the Geofence.
+	Contains(LLA) bool
+
+	// BoundingBox will return a BoundingBox that contains the Geofence,
+	// which is used as a cheap check before calling Contains.
+	BoundingBox() BoundingBox
+}
+
+// Both Circle and Polygon can be used as a Geofence, so that fences of
+// different shapes can be kept in a single []Geofence.
+var (
+	_ Geofence = Circle{}
+	_ Geofence = Polygon{}
+)
+
+// EvaluateFences will return, for each of the Geofences, if the point is
+// inside of it. Each Geofence's BoundingBox is checked first, so that the
+// (much more expensive) Contains is only called on Geofences that might
+// actually contain the point.
+//
+// Since a Polygon's BoundingBox only covers its vertices, Polygons with very
+// long edges (or that contain a pole) can be missed. Those should be split
+// into smaller Polygons first.
+func EvaluateFences(point LLA, fences []Geofence) []bool {
+	inside := make([]bool, len(fences))
+	for i, fence := range fences {
+		inside[i] = fence.BoundingBox().Contains(point) && fence.Contains(point)
+	}
+	return inside
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,57 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateFences(t *testing.T) {
+	fences := []geo.Geofence{
+		box(0, 0, 1, 1),
+		geo.Circle{Center: geo.LLA{Latitude: 10, Longitude: 10}, Radius: 10000},
+		box(-1, 179, 1, -179),
+		geo.Circle{Center: geo.LLA{Latitude: 0.5, Longitude: 0.5}, Radius: 1000},
+	}
+
+	assert.Equal(t,
+		[]bool{true, false, false, false},
+		geo.EvaluateFences(geo.LLA{Latitude: 0.2, Longitude: 0.2}, fences),
+	)
+	assert.Equal(t,
+		[]bool{false, true, false, false},
+		geo.EvaluateFences(geo.LLA{Latitude: 10.05, Longitude: 10}, fences),
+	)
+	assert.Equal(t,
+		[]bool{false, false, true, false},
+		geo.EvaluateFences(geo.LLA{Latitude: 0, Longitude: -179.5}, fences),
+	)
+	assert.Equal(t,
+		[]bool{true, false, false, true},
+		geo.EvaluateFences(geo.LLA{Latitude: 0.5, Longitude: 0.5}, fences),
+	)
+	assert.Empty(t, geo.EvaluateFences(geo.LLA{}, nil))
+}
+
+func TestGeofence(t *testing.T) {
+	var (
+		square = box(0, 0, 1, 1)
+		circle = geo.Circle{Center: geo.LLA{Latitude: 10, Longitude: 10}, Radius: 10000}
+		fences = []geo.Geofence{square, circle}
+	)
+
+	for _, point := range []geo.LLA{
+		{Latitude: 0.5, Longitude: 0.5},
+		{Latitude: 1.5, Longitude: 0.5},
+		{Latitude: 10.05, Longitude: 10},
+		{Latitude: 10.1, Longitude: 10},
+	} {
+		assert.Equal(t, square.Contains(point), fences[0].Contains(point))
+		assert.Equal(t, circle.Contains(point), fences[1].Contains(point))
+	}
+
+	assert.Equal(t, square.BoundingBox(), fences[0].BoundingBox())
+	assert.Equal(t, circle.BoundingBox(), fences[1].BoundingBox())
+}
@@ -0,0 +1,218 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// geohashAlphabet is the base-32 alphabet used by geohashes.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// EncodeGeohash will return the geohash of the LLA, with the given number of
+// characters of precision. Each character narrows down the cell by 5 bits,
+// alternating between Longitude and Latitude -- 5 characters is a cell
+// around 5km wide, 9 characters is a few meters. Altitude is ignored.
+//
+// A precision under 1 will return an empty string.
+func EncodeGeohash(l LLA, precision int) string {
+	if precision < 1 {
+		return ""
+	}
+
+	var (
+		lat = l.Latitude.F64()
+		lon = normalizeLongitude(l.Longitude).F64()
+
+		latRange = [2]float64{-90, 90}
+		lonRange = [2]float64{-180, 180}
+
+		hash strings.Builder
+		even = true
+	)
+
+	for hash.Len() < precision {
+		var index int
+		for bit := 4; bit >= 0; bit-- {
+			value, span := lat, &latRange
+			if even {
+				value, span = lon, &lonRange
+			}
+			mid := (span[0] + span[1]) / 2
+			if value >= mid {
+				index |= 1 << uint(bit)
+				span[0] = mid
+			} else {
+				span[1] = mid
+			}
+			even = !even
+		}
+		hash.WriteByte(geohashAlphabet[index])
+	}
+	return hash.String()
+}
+
+// GeohashBounds will return the BoundingBox covered by the geohash cell. Each
+// additional character of precision shrinks the box by a factor of 32. An
+// error is returned if the geohash is empty, or isn't valid base-32.
+func GeohashBounds(hash string) (BoundingBox, error) {
+	if len(hash) == 0 {
+		return BoundingBox{}, fmt.Errorf("geo.GeohashBounds: empty geohash")
+	}
+
+	var (
+		latRange = [2]float64{-90, 90}
+		lonRange = [2]float64{-180, 180}
+		even     = true
+	)
+
+	for _, c := range strings.ToLower(hash) {
+		index := strings.IndexRune(geohashAlphabet, c)
+		if index < 0 {
+			return BoundingBox{}, fmt.Errorf("geo.GeohashBounds: invalid character %q in geohash %q", c, hash)
+		}
+		for bit := 4; bit >= 0; bit-- {
+			span := &latRange
+			if even {
+				span = &lonRange
+			}
+			mid := (span[0] + span[1]) / 2
+			if index&(1<<uint(bit)) != 0 {
+				span[0] = mid
+			} else {
+				span[1] = mid
+			}
+			even = !even
+		}
+	}
+
+	return BoundingBox{
+		South: Degrees(latRange[0]),
+		West:  Degrees(lonRange[0]),
+		North: Degrees(latRange[1]),
+		East:  Degrees(lonRange[1]),
+	}, nil
+}
+
+// DecodeGeohash will return the center of the geohash cell, with an Altitude
+// of 0. An error is returned if the geohash is empty, or isn't valid base-32.
+func DecodeGeohash(hash string) (LLA, error) {
+	box, err := GeohashBounds(hash)
+	if err != nil {
+		return LLA{}, err
+	}
+	return box.Center(), nil
+}
+
+// GeohashNeighbors will return the eight geohash cells around the provided
+// geohash, at the same precision, in the order North, North-East, East,
+// South-East, South, South-West, West, North-West.
+//
+// Neighbors across the anti-meridian wrap around to the other side of the
+// world. There's nothing North of a cell touching the North pole (or South
+// of one touching the South pole), so those neighbors are empty strings, as
+// are all of the neighbors of an invalid geohash.
+func GeohashNeighbors(hash string) [8]string {
+	var neighbors [8]string
+
+	box, err := GeohashBounds(hash)
+	if err != nil {
+		return neighbors
+	}
+
+	var (
+		height = box.North - box.South
+		width  = box.East - box.West
+		center = box.Center()
+	)
+
+	for i, offset := range [8][2]Degrees{
+		{1, 0}, {1, 1}, {0, 1}, {-1, 1},
+		{-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+	} {
+		lat := center.Latitude + offset[0]*height
+		if lat > 90 || lat < -90 {
+			continue
+		}
+		neighbors[i] = EncodeGeohash(LLA{
+			Latitude:  lat,
+			Longitude: center.Longitude + offset[1]*width,
+		}, len(hash))
+	}
+	return neighbors
+}
+
+// GeohashCover will return every geohash cell at the given precision that
+// overlaps with the BoundingBox, going row by row from the South-West corner.
+// BoundingBoxes that cross the anti-meridian are covered on both sides of it.
+//
+// The number of cells grows very quickly with the precision (32 times for
+// each additional character), so take care to pick a precision that's in
+// line with the size of the box. A precision under 1 will return nil.
+func GeohashCover(box BoundingBox, precision int) []string {
+	if precision < 1 {
+		return nil
+	}
+
+	var (
+		lonBits = (5*precision + 1) / 2
+		latBits = 5 * precision / 2
+
+		rows = math.Pow(2, float64(latBits))
+		cols = math.Pow(2, float64(lonBits))
+
+		height = 180 / rows
+		width  = 360 / cols
+
+		west = normalizeLongitude(box.West).F64()
+		span = (box.East - box.West).F64()
+	)
+
+	if span < 0 {
+		span += 360
+	}
+	east := west + math.Min(span, 360)
+
+	// Cells that only touch the box along the North or East edge don't
+	// overlap with it, so those edges are rounded down.
+	var (
+		firstRow = math.Floor((box.South.F64() + 90) / height)
+		lastRow  = math.Max(firstRow, math.Min(rows, math.Ceil((box.North.F64()+90)/height))-1)
+
+		firstCol = math.Floor((west + 180) / width)
+		lastCol  = math.Max(firstCol, math.Min(firstCol+cols, math.Ceil((east+180)/width))-1)
+	)
+
+	hashes := []string{}
+	for row := firstRow; row <= lastRow; row++ {
+		for col := firstCol; col <= lastCol; col++ {
+			hashes = append(hashes, EncodeGeohash(LLA{
+				Latitude:  Degrees(-90 + (row+0.5)*height),
+				Longitude: Degrees(-180 + (math.Mod(col, cols)+0.5)*width),
+			}, precision))
+		}
+	}
+	return hashes
+}
+
+// vim: foldmethod=marker
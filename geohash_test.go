@@ -0,0 +1,136 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeGeohash(t *testing.T) {
+	assert.Equal(t, "ezs42", geo.EncodeGeohash(geo.LLA{Latitude: 42.6, Longitude: -5.6}, 5))
+	assert.Equal(t, "u4pruydqqvj", geo.EncodeGeohash(geo.LLA{Latitude: 57.64911, Longitude: 10.40744}, 11))
+	assert.Equal(t, "", geo.EncodeGeohash(geo.LLA{}, 0))
+}
+
+func TestDecodeGeohash(t *testing.T) {
+	lla, err := geo.DecodeGeohash("ezs42")
+	assert.NoError(t, err)
+	assert.InDelta(t, 42.6, lla.Latitude.F64(), 0.03)
+	assert.InDelta(t, -5.6, lla.Longitude.F64(), 0.03)
+
+	lla, err = geo.DecodeGeohash("EZS42")
+	assert.NoError(t, err)
+	assert.InDelta(t, 42.6, lla.Latitude.F64(), 0.03)
+
+	_, err = geo.DecodeGeohash("")
+	assert.Error(t, err)
+	_, err = geo.DecodeGeohash("ezs4a")
+	assert.Error(t, err)
+}
+
+func TestGeohashNeighbors(t *testing.T) {
+	assert.Equal(t, [8]string{
+		"gbsvj", "gbsvn", "gbsuy", "gbsuw",
+		"gbsut", "gbsus", "gbsuu", "gbsvh",
+	}, geo.GeohashNeighbors("gbsuv"))
+
+	assert.Equal(t, [8]string{
+		"dqcjqf", "dqcjr4", "dqcjr1", "dqcjr0",
+		"dqcjqb", "dqcjq8", "dqcjq9", "dqcjqd",
+	}, geo.GeohashNeighbors("dqcjqc"))
+
+	// Across a parent cell boundary.
+	assert.Equal(t, [8]string{
+		"ezs48", "ezs49", "ezs43", "ezs41",
+		"ezs40", "ezefp", "ezefr", "ezefx",
+	}, geo.GeohashNeighbors("ezs42"))
+}
+
+func TestGeohashNeighborsEdges(t *testing.T) {
+	// "b" is the cell in the North-West corner of the world.
+	neighbors := geo.GeohashNeighbors("b")
+	assert.Equal(t, "", neighbors[0])
+	assert.Equal(t, "", neighbors[1])
+	assert.Equal(t, "c", neighbors[2])
+	assert.Equal(t, "8", neighbors[4])
+	assert.Equal(t, "z", neighbors[6])
+	assert.Equal(t, "", neighbors[7])
+
+	// "0" is in the South-West corner, so West wraps around to "p".
+	neighbors = geo.GeohashNeighbors("0")
+	assert.Equal(t, "2", neighbors[0])
+	assert.Equal(t, "", neighbors[4])
+	assert.Equal(t, "p", neighbors[6])
+	assert.Equal(t, "r", neighbors[7])
+
+	assert.Equal(t, [8]string{}, geo.GeohashNeighbors("not a geohash"))
+}
+
+func TestGeohashBounds(t *testing.T) {
+	hash := geo.EncodeGeohash(geo.LLA{Latitude: 38.897957, Longitude: -77.036560}, 9)
+
+	var previous geo.BoundingBox
+	for precision := 1; precision <= len(hash); precision++ {
+		box, err := geo.GeohashBounds(hash[:precision])
+		assert.NoError(t, err)
+
+		center, err := geo.DecodeGeohash(hash[:precision])
+		assert.NoError(t, err)
+		assert.Equal(t, center, box.Center())
+
+		assert.True(t, box.South <= 38.897957 && 38.897957 <= box.North)
+		assert.True(t, box.West <= -77.036560 && -77.036560 <= box.East)
+
+		if precision > 1 {
+			assert.Less(t, (box.East - box.West).F64(), (previous.East - previous.West).F64())
+			assert.LessOrEqual(t, (box.North - box.South).F64(), (previous.North - previous.South).F64())
+		}
+		previous = box
+	}
+
+	box, err := geo.GeohashBounds("ezs42")
+	assert.NoError(t, err)
+	assert.InDelta(t, 42.583, box.South.F64(), 1e-3)
+	assert.InDelta(t, 42.627, box.North.F64(), 1e-3)
+	assert.InDelta(t, -5.625, box.West.F64(), 1e-3)
+	assert.InDelta(t, -5.581, box.East.F64(), 1e-3)
+
+	_, err = geo.GeohashBounds("i")
+	assert.Error(t, err)
+}
+
+func TestGeohashCover(t *testing.T) {
+	// A small box around the North-East corner of "ezs42".
+	box := geo.BoundingBox{South: 42.62, West: -5.59, North: 42.63, East: -5.57}
+	assert.Equal(t, []string{"ezs42", "ezs43", "ezs48", "ezs49"}, geo.GeohashCover(box, 5))
+
+	// Entirely inside of one cell.
+	box = geo.BoundingBox{South: 42.59, West: -5.62, North: 42.60, East: -5.61}
+	assert.Equal(t, []string{"ezs42"}, geo.GeohashCover(box, 5))
+
+	// Exactly the bounds of a cell doesn't pull in the neighbors.
+	bounds, err := geo.GeohashBounds("ezs42")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ezs42"}, geo.GeohashCover(bounds, 5))
+
+	assert.Nil(t, geo.GeohashCover(box, 0))
+}
+
+func TestGeohashCoverAntiMeridian(t *testing.T) {
+	box := geo.BoundingBox{South: 10, West: 179.9, North: 10.1, East: -179.9}
+	hashes := geo.GeohashCover(box, 3)
+	assert.Len(t, hashes, 2)
+
+	for _, hash := range hashes {
+		center, err := geo.DecodeGeohash(hash)
+		assert.NoError(t, err)
+		assert.InDelta(t, 180, math.Abs(center.Longitude.F64()), 1)
+	}
+
+	// The whole world at one character is every cell.
+	box = geo.BoundingBox{South: -90, West: -180, North: 90, East: 180}
+	assert.Len(t, geo.GeohashCover(box, 1), 32)
+}
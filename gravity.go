@@ -0,0 +1,107 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+var (
+	// wgs84GammaE is the normal gravity at the Equator, in m/s².
+	wgs84GammaE = 9.7803253359
+
+	// wgs84GravityK is the Somigliana formula constant k.
+	wgs84GravityK = 0.00193185265241
+
+	// wgs84GravityM is ω²a²b/GM, the ratio of the centrifugal force to
+	// gravity at the Equator.
+	wgs84GravityM = 0.00344978650684
+
+	// wgs84Omega is the rotation rate of the Earth, in Radians per second.
+	wgs84Omega = 7.292115e-5
+)
+
+// checkLatitude will return an error (prefixed with the provided name of the
+// function) if the Latitude is not within [-90, 90].
+func checkLatitude(name string, lat Degrees) error {
+	if lat < -90 || lat > 90 || math.IsNaN(lat.F64()) {
+		return fmt.Errorf("%s: Latitude must be within [-90, 90]", name)
+	}
+	return nil
+}
+
+// NormalGravity will return the WGS84 normal gravity vector at the provided
+// LLA, in m/s², in the local ENU tangent plane. Since normal gravity points
+// along the ellipsoidal normal, this is entirely in the (negative) Up
+// component.
+//
+// The magnitude on the surface comes from the Somigliana formula, which goes
+// from about 9.780 m/s² at the Equator to about 9.832 m/s² at the poles,
+// with the free-air correction for Altitude applied on top of that. The
+// free-air correction is a series expansion, and is only good for Altitudes
+// within the atmosphere.
+func NormalGravity(lla LLA) (ENU, error) {
+	if err := checkLatitude("geo.NormalGravity", lla.Latitude); err != nil {
+		return ENU{}, err
+	}
+
+	var (
+		sinLat   = math.Sin(lla.Latitude.Radians().F64())
+		sinLatSq = sinLat * sinLat
+		h        = lla.Altitude.F64()
+
+		surface = wgs84GammaE * (1 + wgs84GravityK*sinLatSq) / math.Sqrt(1-wgs84ESq*sinLatSq)
+		gamma   = surface * (1 -
+			2/wgs84A*(1+wgs84F+wgs84GravityM-2*wgs84F*sinLatSq)*h +
+			3*h*h/wgs84ASq)
+	)
+
+	return ENU{Up: Meters(-gamma)}, nil
+}
+
+// CoriolisAcceleration will return the Coriolis acceleration (in m/s²) felt
+// by something moving at the provided velocity (in m/s, in the local ENU
+// tangent plane at the LLA), due to the rotation of the Earth. This is
+// -2 ω × v, where ω is the rotation of the Earth, as seen in the ENU frame.
+//
+// In the Northern hemisphere, this pushes things moving along the surface to
+// their right, and things moving East are pushed up, away from the axis of
+// rotation.
+func CoriolisAcceleration(lla LLA, velocity ENU) ENU {
+	var (
+		lat = lla.Latitude.Radians().F64()
+		wN  = wgs84Omega * math.Cos(lat)
+		wU  = wgs84Omega * math.Sin(lat)
+
+		vE = velocity.East.F64()
+		vN = velocity.North.F64()
+		vU = velocity.Up.F64()
+	)
+
+	return ENU{
+		East:  Meters(-2 * (wN*vU - wU*vN)),
+		North: Meters(-2 * wU * vE),
+		Up:    Meters(2 * wN * vE),
+	}
+}
+
+// vim: foldmethod=marker
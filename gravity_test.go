@@ -0,0 +1,69 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalGravity(t *testing.T) {
+	equator, err := geo.NormalGravity(geo.LLA{})
+	assert.NoError(t, err)
+	assert.Equal(t, geo.Meters(0), equator.East)
+	assert.Equal(t, geo.Meters(0), equator.North)
+	assert.InEpsilon(t, -9.7803253359, equator.Up.F64(), 1e-9)
+
+	pole, err := geo.NormalGravity(geo.LLA{Latitude: 90})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, -9.8321849378, pole.Up.F64(), 1e-9)
+
+	south, err := geo.NormalGravity(geo.LLA{Latitude: -90})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, pole.Up.F64(), south.Up.F64(), 1e-12)
+}
+
+func TestNormalGravityAltitude(t *testing.T) {
+	surface, err := geo.NormalGravity(geo.LLA{Latitude: 45})
+	assert.NoError(t, err)
+
+	// Gravity drops off by about 3.086e-6 m/s² per meter up.
+	above, err := geo.NormalGravity(geo.LLA{Latitude: 45, Altitude: 1000})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 3.086e-3, (above.Up - surface.Up).F64(), 1e-2)
+
+	_, err = geo.NormalGravity(geo.LLA{Latitude: 91})
+	assert.Error(t, err)
+}
+
+func TestCoriolisAcceleration(t *testing.T) {
+	omega := 7.292115e-5
+
+	// Heading East at the Equator pushes straight up.
+	a := geo.CoriolisAcceleration(geo.LLA{}, geo.ENU{East: 100})
+	assert.InDelta(t, 0, a.East.F64(), 1e-12)
+	assert.InDelta(t, 0, a.North.F64(), 1e-12)
+	assert.InEpsilon(t, 2*omega*100, a.Up.F64(), 1e-9)
+
+	// Heading North at the Equator is along the axis of rotation.
+	a = geo.CoriolisAcceleration(geo.LLA{}, geo.ENU{North: 100})
+	assert.InDelta(t, 0, a.Distance().F64(), 1e-12)
+
+	// Heading North in the Northern hemisphere pushes to the right (East),
+	// and in the Southern hemisphere, to the left (West).
+	a = geo.CoriolisAcceleration(geo.LLA{Latitude: 45}, geo.ENU{North: 100})
+	assert.InEpsilon(t, 2*omega*100*math.Sin(math.Pi/4), a.East.F64(), 1e-9)
+	a = geo.CoriolisAcceleration(geo.LLA{Latitude: -45}, geo.ENU{North: 100})
+	assert.InEpsilon(t, -2*omega*100*math.Sin(math.Pi/4), a.East.F64(), 1e-9)
+
+	// Heading East at the North Pole pushes to the right (South).
+	a = geo.CoriolisAcceleration(geo.LLA{Latitude: 90}, geo.ENU{East: 100})
+	assert.InEpsilon(t, -2*omega*100, a.North.F64(), 1e-9)
+	assert.InDelta(t, 0, a.Up.F64(), 1e-12)
+
+	// Falling straight down at the Equator pushes East.
+	a = geo.CoriolisAcceleration(geo.LLA{}, geo.ENU{Up: -100})
+	assert.InEpsilon(t, 2*omega*100, a.East.F64(), 1e-9)
+}
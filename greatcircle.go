@@ -0,0 +1,406 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// normalizeLongitude will wrap the Longitude to be within [-180, 180).
+func normalizeLongitude(lon Degrees) Degrees {
+	if lon >= -180 && lon < 180 {
+		return lon
+	}
+	l := math.Mod(lon.F64()+180, 360)
+	if l < 0 {
+		l += 360
+	}
+	return Degrees(l - 180)
+}
+
+// normalizeBearing will wrap the bearing to be within [0, 360).
+func normalizeBearing(bearing Degrees) Degrees {
+	return normalizeLongitude(bearing-180) + 180
+}
+
+// InitialBearing will return the bearing (in Degrees clockwise from North,
+// within [0, 360)) that one would start off on when traveling along a great
+// circle from the origin to the position.
+//
+// Since great circles (other than the Equator and the meridians) don't keep a
+// constant bearing, this is only the bearing at the origin. Altitude is
+// ignored.
+func InitialBearing(origin, position LLA) Degrees {
+	var (
+		lat1  = origin.Latitude.Radians().F64()
+		lat2  = position.Latitude.Radians().F64()
+		dLon  = (position.Longitude - origin.Longitude).Radians().F64()
+		y     = math.Sin(dLon) * math.Cos(lat2)
+		x     = math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+		theta = Radians(math.Atan2(y, x)).Degrees()
+	)
+	return normalizeBearing(theta)
+}
+
+// MeridianConvergence will return how much the bearing changes over the
+// course of traveling along a great circle from a to b, which is to say, the
+// final bearing (on arrival at b) less the initial bearing (on departure from
+// a), within [-180, 180).
+//
+// This comes from the meridians converging towards the poles, so it's close
+// to 0 near the Equator, and grows with Latitude and the difference in
+// Longitude. This is not the same as the grid convergence of a map
+// projection. Altitude is ignored.
+func MeridianConvergence(a, b LLA) Degrees {
+	var (
+		initial = InitialBearing(a, b)
+		final   = InitialBearing(b, a) + 180
+	)
+	return normalizeLongitude(final - initial)
+}
+
+// NearestByBearing will return the point of the path whose bearing from ref
+// (the InitialBearing of the great circle from ref to the point) is closest
+// to the provided bearing, in Degrees clockwise from North. Bearings wrap, so
+// a bearing of 359 is only 2 Degrees away from a bearing of 1.
+//
+// Points at the very same location as ref don't have a bearing, and are
+// skipped. If there are no points left to pick from, an error is returned.
+// If two points are exactly as close, the first one wins. Altitude is
+// ignored.
+func NearestByBearing(path []LLA, ref LLA, bearing Degrees) (LLA, error) {
+	var (
+		r       = unitVector(ref)
+		found   bool
+		nearest LLA
+		best    Degrees
+	)
+
+	for _, point := range path {
+		if r.angle(unitVector(point)) == 0 {
+			continue
+		}
+		diff := normalizeLongitude(InitialBearing(ref, point) - bearing)
+		if diff < 0 {
+			diff = -diff
+		}
+		if !found || diff < best {
+			found = true
+			nearest = point
+			best = diff
+		}
+	}
+
+	if !found {
+		return LLA{}, fmt.Errorf("geo.NearestByBearing: no points with a bearing from ref")
+	}
+	return nearest, nil
+}
+
+// TurnAngle will return how far one has to turn at the vertex when traveling
+// along great circles from prev, to the vertex, and then on to next. An angle
+// of 0 is straight ahead, positive angles are turns to the right, and
+// negative angles are turns to the left, within [-180, 180).
+//
+// If the vertex is at the same location as prev or next, there's no bearing
+// to turn from (or to), and an error is returned.
+func TurnAngle(prev, vertex, next LLA) (Degrees, error) {
+	v := unitVector(vertex)
+	if v.angle(unitVector(prev)) == 0 || v.angle(unitVector(next)) == 0 {
+		return 0, fmt.Errorf("geo.TurnAngle: vertex is at the same location as a neighbor")
+	}
+
+	var (
+		inbound  = InitialBearing(vertex, prev) + 180
+		outbound = InitialBearing(vertex, next)
+	)
+	return normalizeLongitude(outbound - inbound), nil
+}
+
+// SphericalAngle will return the interior angle at the vertex of the
+// spherical triangle formed by the vertex, a and b -- the angle between the
+// great circle from the vertex to a and the great circle from the vertex to
+// b, within [0, 180].
+//
+// Unlike a flat triangle, the three angles of a spherical triangle add up to
+// more than 180 Degrees, by an amount (the spherical excess) proportional to
+// its area. If a or b are at the same location as the vertex, there's no
+// angle to measure, and 0 is returned. This treats the Earth as a sphere, and
+// ignores Altitude.
+func SphericalAngle(vertex, a, b LLA) Degrees {
+	var (
+		v  = unitVector(vertex)
+		na = v.cross(unitVector(a))
+		nb = v.cross(unitVector(b))
+	)
+	if na.norm() == 0 || nb.norm() == 0 {
+		return 0
+	}
+	return na.angle(nb).Degrees()
+}
+
+// RotationAxis will return the unit vector normal to the plane of the great
+// circle through a and b, which is the axis that a would be rotated around
+// (counter-clockwise, looking down the axis) to get to b. This is the cross
+// product of the unit vectors pointing at a and b, normalized, and returned
+// as an XYZ of length 1 (even though it's not really in Meters).
+//
+// If a and b are the same point, or exactly opposite each other, there's no
+// single great circle through them, and the zero XYZ is returned. This
+// treats the Earth as a sphere, and ignores Altitude.
+func RotationAxis(a, b LLA) XYZ {
+	axis := unitVector(a).cross(unitVector(b))
+	if axis.norm() < 1e-12 {
+		return XYZ{}
+	}
+	return axis.unit().xyz()
+}
+
+// segmentEpsilon is the slack given when checking if a point on a great
+// circle is within a segment of it, so that segments that touch exactly at
+// an end are still considered to intersect.
+const segmentEpsilon = 1e-12
+
+// onSegment will return true if p (which is on the great circle with the
+// pole n) is between a and b.
+func onSegment(p, a, b, n vector) bool {
+	return a.cross(p).dot(n) >= -segmentEpsilon && p.cross(b).dot(n) >= -segmentEpsilon
+}
+
+// segmentsIntersect will return the point where the great circle segment
+// from a1 to a2 crosses the great circle segment from b1 to b2, all as unit
+// vectors, and false if they don't cross at all.
+//
+// Segments along the very same great circle either don't touch, or overlap
+// along a stretch rather than crossing at a point, so they're never
+// considered to intersect.
+func segmentsIntersect(a1, a2, b1, b2 vector) (vector, bool) {
+	var (
+		na = a1.cross(a2)
+		nb = b1.cross(b2)
+		l  = na.cross(nb)
+	)
+
+	if na.norm() == 0 || nb.norm() == 0 || l.norm() < segmentEpsilon {
+		return vector{}, false
+	}
+
+	na = na.unit()
+	nb = nb.unit()
+	l = l.unit()
+
+	for _, p := range []vector{l, l.scale(-1)} {
+		if onSegment(p, a1, a2, na) && onSegment(p, b1, b2, nb) {
+			return p, true
+		}
+	}
+	return vector{}, false
+}
+
+// SegmentsIntersect will return the point where the great circle segment from
+// a1 to a2 crosses the great circle segment from b1 to b2, and true, or false
+// if they don't cross.
+//
+// Any two great circles cross at two points on opposite sides of the Earth,
+// so the trick is checking that one of those points is actually within both
+// segments. Segments that just touch at an end are considered to cross, but
+// segments along the same great circle (whether they overlap or not) are
+// not. This treats the Earth as a sphere, ignores Altitude, and the returned
+// point has an Altitude of 0.
+func SegmentsIntersect(a1, a2, b1, b2 LLA) (LLA, bool) {
+	p, ok := segmentsIntersect(unitVector(a1), unitVector(a2), unitVector(b1), unitVector(b2))
+	if !ok {
+		return LLA{}, false
+	}
+	return p.lla(), true
+}
+
+// CrossedLine will return true if moving from prev to curr (along a great
+// circle) crossed the great circle line segment from lineStart to lineEnd,
+// such as a geofence boundary, along with which way it was crossed.
+//
+// The direction is 1 if the movement crossed from the left of the line to the
+// right of it (as seen looking from lineStart towards lineEnd), -1 if it
+// crossed from the right to the left, and 0 if it didn't cross at all.
+//
+// Unlike SegmentsIntersect, only ending up strictly on the other side of the
+// line counts -- a point exactly on the line is still on the side it came
+// from. Landing on the line isn't a crossing, and neither is moving off of
+// it, so a track that touches the line and goes back doesn't cross it twice.
+// To catch a track that crosses by way of a point on the line, pass the last
+// point that wasn't on the line as prev. This treats the Earth as a sphere,
+// and ignores Altitude.
+func CrossedLine(prev, curr LLA, lineStart, lineEnd LLA) (crossed bool, direction int) {
+	var (
+		p = unitVector(prev)
+		c = unitVector(curr)
+		a = unitVector(lineStart)
+		b = unitVector(lineEnd)
+
+		// The pole of the line is on its left, so this is positive to the
+		// left of the line, and negative to the right of it.
+		n    = a.cross(b)
+		from = n.dot(p)
+		to   = n.dot(c)
+	)
+
+	if from*to >= 0 {
+		return false, 0
+	}
+	if _, ok := segmentsIntersect(p, c, a, b); !ok {
+		return false, 0
+	}
+
+	if from > 0 {
+		return true, 1
+	}
+	return true, -1
+}
+
+// angleToSegment will return the angle between p and the closest point on
+// the great circle segment from a to b, all as unit vectors.
+func angleToSegment(p, a, b vector) Radians {
+	n := a.cross(b)
+	if n.norm() != 0 {
+		n = n.unit()
+
+		// Project p onto the plane of the great circle, and check if that's
+		// between a and b. If it is, that's the closest point.
+		c := p.sub(n.scale(p.dot(n)))
+		if c.norm() != 0 && a.cross(c).dot(n) >= 0 && c.cross(b).dot(n) >= 0 {
+			return p.angle(c.unit())
+		}
+	}
+
+	// Otherwise, it's whichever end is closer.
+	return Radians(math.Min(p.angle(a).F64(), p.angle(b).F64()))
+}
+
+// Collinear will return true if the three points all lie along a common
+// great circle, give or take the tolerance. The order of the points doesn't
+// matter -- the two points furthest apart define the great circle, and the
+// remaining point is checked to be within the tolerance of it (measured at a
+// right angle to the great circle, the cross-track distance).
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude.
+func Collinear(a, b, c LLA, tolerance Meters) bool {
+	var (
+		va = unitVector(a)
+		vb = unitVector(b)
+		vc = unitVector(c)
+	)
+
+	// Put the two points furthest apart into va and vb.
+	if ac, ab := va.angle(vc), va.angle(vb); ac > ab {
+		vb, vc = vc, vb
+	}
+	if bc, ab := vb.angle(vc), va.angle(vb); bc > ab {
+		va, vc = vc, va
+	}
+
+	n := va.cross(vb)
+	if n.norm() == 0 {
+		// va and vb are either the same point, or exactly opposite each
+		// other, so there's a great circle through them and any other point.
+		return true
+	}
+
+	crossTrack := math.Abs(math.Asin(math.Max(-1, math.Min(1, n.unit().dot(vc)))))
+	return crossTrack*earthRadiusMeters <= tolerance.F64()
+}
+
+// DestinationPoint will return the point reached by travelling the provided
+// distance along a great circle from the origin, starting off at the
+// provided bearing (in Degrees clockwise from North).
+//
+// This uses the same spherical Earth as HaversineDistance. The Altitude of
+// the origin is carried over to the returned point as-is.
+func DestinationPoint(origin LLA, bearing Degrees, distance Meters) LLA {
+	var (
+		lat   = origin.Latitude.Radians().F64()
+		lon   = origin.Longitude.Radians().F64()
+		theta = bearing.Radians().F64()
+		delta = distance.F64() / earthRadiusMeters
+
+		lat2 = math.Asin(math.Sin(lat)*math.Cos(delta) + math.Cos(lat)*math.Sin(delta)*math.Cos(theta))
+		lon2 = lon + math.Atan2(
+			math.Sin(theta)*math.Sin(delta)*math.Cos(lat),
+			math.Cos(delta)-math.Sin(lat)*math.Sin(lat2),
+		)
+	)
+
+	return LLA{
+		Latitude:  Radians(lat2).Degrees(),
+		Longitude: normalizeLongitude(Radians(lon2).Degrees()),
+		Altitude:  origin.Altitude,
+	}
+}
+
+// IntermediatePoint will return the point the provided fraction of the way
+// along the great circle from a to b, where a fraction of 0 returns a, and a
+// fraction of 1 returns b.
+//
+// The interpolation is done on unit vectors, so it always takes the short way
+// around the world -- including across the anti-meridian -- and the returned
+// Longitude is always within [-180, 180]. The Altitude is linearly
+// interpolated between the two points. Points on opposite sides of the Earth
+// don't have a single great circle between them, and the result is not
+// meaningful.
+func IntermediatePoint(a, b LLA, fraction float64) LLA {
+	var (
+		va    = unitVector(a)
+		vb    = unitVector(b)
+		theta = va.angle(vb).F64()
+		point LLA
+	)
+
+	if sin := math.Sin(theta); sin == 0 {
+		point = va.lla()
+	} else {
+		point = va.scale(math.Sin((1-fraction)*theta) / sin).add(
+			vb.scale(math.Sin(fraction*theta) / sin),
+		).lla()
+	}
+
+	point.Altitude = a.Altitude + Meters(fraction)*(b.Altitude-a.Altitude)
+	return point
+}
+
+// GreatCirclePath will return the provided number of points evenly spaced
+// along the great circle from a to b, including both a and b. See
+// IntermediatePoint for how each point is computed.
+//
+// A path needs at least 2 points; asking for fewer will return nil.
+func GreatCirclePath(a, b LLA, points int) []LLA {
+	if points < 2 {
+		return nil
+	}
+
+	path := make([]LLA, points)
+	for i := range path {
+		path[i] = IntermediatePoint(a, b, float64(i)/float64(points-1))
+	}
+	return path
+}
+
+// vim: foldmethod=marker
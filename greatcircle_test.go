@@ -0,0 +1,373 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDestinationPoint(t *testing.T) {
+	origin := geo.LLA{Latitude: 10, Longitude: 20, Altitude: 5}
+
+	// One degree of arc, due North.
+	north := geo.DestinationPoint(origin, 0, geo.Meters(geo.Degrees(1).Radians().F64()*6371000))
+	assert.InEpsilon(t, 11, north.Latitude.F64(), 1e-12)
+	assert.InEpsilon(t, 20, north.Longitude.F64(), 1e-12)
+	assert.Equal(t, geo.Meters(5), north.Altitude)
+
+	from := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+	to := geo.DestinationPoint(from, 51.2, 5897658)
+	meters, err := geo.HaversineDistance(from, to)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 5897658, meters.F64(), 1e-9)
+
+	// Going East along the equator across the anti-meridian.
+	east := geo.DestinationPoint(geo.LLA{Longitude: 179}, 90, geo.Meters(geo.Degrees(2).Radians().F64()*6371000))
+	assert.InDelta(t, 0, east.Latitude.F64(), 1e-9)
+	assert.InEpsilon(t, -179, east.Longitude.F64(), 1e-9)
+}
+
+func TestInitialBearing(t *testing.T) {
+	origin := geo.LLA{Latitude: 10, Longitude: 20}
+
+	assert.InDelta(t, 0, geo.InitialBearing(origin, geo.LLA{Latitude: 11, Longitude: 20}).F64(), 1e-9)
+	assert.InDelta(t, 180, geo.InitialBearing(origin, geo.LLA{Latitude: 9, Longitude: 20}).F64(), 1e-9)
+	assert.InDelta(t, 90, geo.InitialBearing(geo.LLA{}, geo.LLA{Longitude: 1}).F64(), 1e-9)
+	assert.InDelta(t, 270, geo.InitialBearing(geo.LLA{}, geo.LLA{Longitude: -1}).F64(), 1e-9)
+
+	// Across the anti-meridian is still due East.
+	assert.InDelta(t, 90, geo.InitialBearing(geo.LLA{Longitude: 179}, geo.LLA{Longitude: -179}).F64(), 1e-9)
+
+	to := geo.DestinationPoint(origin, 123, 100000)
+	assert.InEpsilon(t, 123, geo.InitialBearing(origin, to).F64(), 1e-9)
+}
+
+func TestTurnAngle(t *testing.T) {
+	var (
+		prev   = geo.LLA{Latitude: -1, Longitude: 0}
+		vertex = geo.LLA{Latitude: 0, Longitude: 0}
+	)
+
+	right, err := geo.TurnAngle(prev, vertex, geo.LLA{Latitude: 0, Longitude: 1})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 90, right.F64(), 1e-9)
+
+	left, err := geo.TurnAngle(prev, vertex, geo.LLA{Latitude: 0, Longitude: -1})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, -90, left.F64(), 1e-9)
+
+	straight, err := geo.TurnAngle(prev, vertex, geo.LLA{Latitude: 1, Longitude: 0})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0, straight.F64(), 1e-9)
+
+	_, err = geo.TurnAngle(prev, vertex, vertex)
+	assert.Error(t, err)
+	_, err = geo.TurnAngle(vertex, vertex, prev)
+	assert.Error(t, err)
+}
+
+func TestIntermediatePoint(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 10, Longitude: 20, Altitude: 0}
+		b = geo.LLA{Latitude: 30, Longitude: 40, Altitude: 100}
+	)
+
+	total, err := geo.HaversineDistance(a, geo.LLA{Latitude: b.Latitude, Longitude: b.Longitude})
+	assert.NoError(t, err)
+
+	quarter := geo.IntermediatePoint(a, b, 0.25)
+	assert.Equal(t, geo.Meters(25), quarter.Altitude)
+
+	quarter.Altitude = 0
+	meters, err := geo.HaversineDistance(a, quarter)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, total.F64()/4, meters.F64(), 1e-9)
+
+	start := geo.IntermediatePoint(a, b, 0)
+	assert.InEpsilon(t, a.Latitude.F64(), start.Latitude.F64(), 1e-12)
+	end := geo.IntermediatePoint(a, b, 1)
+	assert.InEpsilon(t, b.Longitude.F64(), end.Longitude.F64(), 1e-12)
+
+	same := geo.IntermediatePoint(a, a, 0.5)
+	assert.InEpsilon(t, a.Latitude.F64(), same.Latitude.F64(), 1e-12)
+}
+
+func TestIntermediatePointAntiMeridian(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 10, Longitude: 170}
+		b = geo.LLA{Latitude: -10, Longitude: -170}
+	)
+
+	mid := geo.IntermediatePoint(a, b, 0.5)
+	assert.InDelta(t, 0, mid.Latitude.F64(), 1e-9)
+	assert.InDelta(t, 180, math.Abs(mid.Longitude.F64()), 1e-9)
+
+	path := geo.GreatCirclePath(a, b, 11)
+	assert.Len(t, path, 11)
+	for _, point := range path {
+		assert.GreaterOrEqual(t, math.Abs(point.Longitude.F64()), 170-1e-9)
+		assert.LessOrEqual(t, math.Abs(point.Longitude.F64()), 180.0)
+	}
+
+	assert.Nil(t, geo.GreatCirclePath(a, b, 1))
+}
+
+func TestCollinear(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 10, Longitude: -77}
+		b = geo.LLA{Latitude: 20, Longitude: -77}
+		c = geo.LLA{Latitude: 35, Longitude: -77}
+	)
+
+	assert.True(t, geo.Collinear(a, b, c, 1))
+	assert.True(t, geo.Collinear(c, a, b, 1))
+	assert.True(t, geo.Collinear(b, c, a, 1))
+
+	// Along the Equator, with the middle point given last.
+	assert.True(t, geo.Collinear(
+		geo.LLA{Longitude: 0},
+		geo.LLA{Longitude: 10},
+		geo.LLA{Longitude: 5},
+		1,
+	))
+
+	off := geo.LLA{Latitude: 20, Longitude: -76.99}
+	assert.False(t, geo.Collinear(a, off, c, 100))
+	assert.True(t, geo.Collinear(a, off, c, 1100))
+
+	// Two of the same point are always on a great circle with a third.
+	assert.True(t, geo.Collinear(a, a, off, 0.001))
+}
+
+func TestMeridianConvergence(t *testing.T) {
+	// Between two points on the same parallel, the great circle is
+	// symmetric, and the convergence is 2 * atan(tan(dLon/2) * sin(lat)).
+	var (
+		lat      = geo.Degrees(60)
+		dLon     = geo.Degrees(30)
+		expected = geo.Radians(2 * math.Atan(
+			math.Tan(dLon.Radians().F64()/2)*math.Sin(lat.Radians().F64()),
+		)).Degrees()
+	)
+
+	east := geo.MeridianConvergence(
+		geo.LLA{Latitude: lat, Longitude: 0},
+		geo.LLA{Latitude: lat, Longitude: dLon},
+	)
+	assert.InEpsilon(t, expected.F64(), east.F64(), 1e-9)
+	assert.InDelta(t, 26.13, east.F64(), 0.01)
+
+	west := geo.MeridianConvergence(
+		geo.LLA{Latitude: lat, Longitude: dLon},
+		geo.LLA{Latitude: lat, Longitude: 0},
+	)
+	assert.InEpsilon(t, -expected.F64(), west.F64(), 1e-9)
+
+	equator := geo.MeridianConvergence(
+		geo.LLA{Latitude: 0, Longitude: 0},
+		geo.LLA{Latitude: 0, Longitude: 30},
+	)
+	assert.InDelta(t, 0, equator.F64(), 1e-9)
+
+	meridian := geo.MeridianConvergence(
+		geo.LLA{Latitude: 10, Longitude: 20},
+		geo.LLA{Latitude: 50, Longitude: 20},
+	)
+	assert.InDelta(t, 0, meridian.F64(), 1e-9)
+}
+
+func TestRotationAxis(t *testing.T) {
+	// Heading East along the Equator is around +Z, and West is around -Z.
+	axis := geo.RotationAxis(geo.LLA{Longitude: 10}, geo.LLA{Longitude: 50})
+	assert.InDelta(t, 0, axis.X.F64(), 1e-12)
+	assert.InDelta(t, 0, axis.Y.F64(), 1e-12)
+	assert.InEpsilon(t, 1, axis.Z.F64(), 1e-12)
+
+	axis = geo.RotationAxis(geo.LLA{Longitude: 50}, geo.LLA{Longitude: 10})
+	assert.InEpsilon(t, -1, axis.Z.F64(), 1e-12)
+
+	// Along the prime meridian, heading North is around -Y.
+	axis = geo.RotationAxis(geo.LLA{Latitude: 10}, geo.LLA{Latitude: 20})
+	assert.InDelta(t, 0, axis.X.F64(), 1e-12)
+	assert.InEpsilon(t, -1, axis.Y.F64(), 1e-12)
+	assert.InDelta(t, 0, axis.Z.F64(), 1e-12)
+
+	axis = geo.RotationAxis(
+		geo.LLA{Latitude: 38.897957, Longitude: -77.036560},
+		geo.LLA{Latitude: 51.510357, Longitude: -0.116773},
+	)
+	assert.InEpsilon(t, 1, norm(axis), 1e-12)
+
+	assert.Equal(t, geo.XYZ{}, geo.RotationAxis(geo.LLA{Latitude: 10}, geo.LLA{Latitude: 10}))
+	assert.Equal(t, geo.XYZ{}, geo.RotationAxis(geo.LLA{Latitude: 10}, geo.LLA{Latitude: -10, Longitude: 180}))
+}
+
+func TestSegmentsIntersect(t *testing.T) {
+	// Along the Equator, and along a meridian crossing it.
+	crossing, ok := geo.SegmentsIntersect(
+		geo.LLA{Latitude: 0, Longitude: -10},
+		geo.LLA{Latitude: 0, Longitude: 10},
+		geo.LLA{Latitude: -5, Longitude: 3},
+		geo.LLA{Latitude: 5, Longitude: 3},
+	)
+	assert.True(t, ok)
+	assert.True(t, crossing.ApproxEqual(geo.LLA{Latitude: 0, Longitude: 3}, 1e-9, 0))
+
+	// The diagonals of a box cross in the middle, along the great circles.
+	var (
+		a1 = geo.LLA{Latitude: 38, Longitude: -78}
+		a2 = geo.LLA{Latitude: 39, Longitude: -77}
+		b1 = geo.LLA{Latitude: 38, Longitude: -77}
+		b2 = geo.LLA{Latitude: 39, Longitude: -78}
+	)
+	crossing, ok = geo.SegmentsIntersect(a1, a2, b1, b2)
+	assert.True(t, ok)
+	assert.True(t, geo.Collinear(a1, a2, crossing, 1e-3))
+	assert.True(t, geo.Collinear(b1, b2, crossing, 1e-3))
+	assert.InDelta(t, -77.5, crossing.Longitude.F64(), 1e-9)
+
+	// The great circles cross, but past the end of the second segment.
+	_, ok = geo.SegmentsIntersect(
+		geo.LLA{Latitude: 0, Longitude: -10},
+		geo.LLA{Latitude: 0, Longitude: 10},
+		geo.LLA{Latitude: 1, Longitude: 3},
+		geo.LLA{Latitude: 5, Longitude: 3},
+	)
+	assert.False(t, ok)
+
+	// Across the anti-meridian.
+	crossing, ok = geo.SegmentsIntersect(
+		geo.LLA{Latitude: 0, Longitude: 179},
+		geo.LLA{Latitude: 0, Longitude: -179},
+		geo.LLA{Latitude: -1, Longitude: 180},
+		geo.LLA{Latitude: 1, Longitude: 180},
+	)
+	assert.True(t, ok)
+	assert.True(t, crossing.ApproxEqual(geo.LLA{Latitude: 0, Longitude: 180}, 1e-9, 0))
+
+	// Along the same great circle.
+	_, ok = geo.SegmentsIntersect(
+		geo.LLA{Latitude: 0, Longitude: 0},
+		geo.LLA{Latitude: 0, Longitude: 10},
+		geo.LLA{Latitude: 0, Longitude: 5},
+		geo.LLA{Latitude: 0, Longitude: 15},
+	)
+	assert.False(t, ok)
+}
+
+func TestNearestByBearing(t *testing.T) {
+	var (
+		ref = geo.LLA{Latitude: 0, Longitude: 0}
+
+		// A path running North to South, a degree East of ref.
+		path = []geo.LLA{
+			{Latitude: 2, Longitude: 1},
+			{Latitude: 1, Longitude: 1},
+			{Latitude: 0, Longitude: 1},
+			{Latitude: -1, Longitude: 1},
+			{Latitude: -2, Longitude: 1},
+		}
+	)
+
+	point, err := geo.NearestByBearing(path, ref, 90)
+	assert.NoError(t, err)
+	assert.Equal(t, path[2], point)
+
+	point, err = geo.NearestByBearing(path, ref, 45)
+	assert.NoError(t, err)
+	assert.Equal(t, path[1], point)
+
+	// Due North wraps around to the points closest to 0 (or 360).
+	point, err = geo.NearestByBearing(append(path, geo.LLA{Latitude: 2, Longitude: -0.01}), ref, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, geo.LLA{Latitude: 2, Longitude: -0.01}, point)
+
+	_, err = geo.NearestByBearing([]geo.LLA{ref}, ref, 90)
+	assert.Error(t, err)
+
+	_, err = geo.NearestByBearing(nil, ref, 90)
+	assert.Error(t, err)
+}
+
+func TestSphericalAngle(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 0, Longitude: 0}
+		b = geo.LLA{Latitude: 0, Longitude: 90}
+		c = geo.LLA{Latitude: 90, Longitude: 0}
+	)
+
+	// An octant of the sphere has three right angles.
+	assert.InEpsilon(t, 90, geo.SphericalAngle(a, b, c).F64(), 1e-12)
+	assert.InEpsilon(t, 90, geo.SphericalAngle(b, c, a).F64(), 1e-12)
+	assert.InEpsilon(t, 90, geo.SphericalAngle(c, a, b).F64(), 1e-12)
+
+	// A small, roughly equilateral, triangle is nearly flat, but the angles
+	// still add up to a bit more than 180.
+	var (
+		p   = geo.LLA{Latitude: 0, Longitude: 0}
+		q   = geo.LLA{Latitude: 0, Longitude: 10}
+		r   = geo.LLA{Latitude: 8.66, Longitude: 5}
+		sum = geo.SphericalAngle(p, q, r) + geo.SphericalAngle(q, r, p) + geo.SphericalAngle(r, p, q)
+	)
+	assert.Greater(t, sum.F64(), 180.0)
+	assert.Less(t, sum.F64(), 181.0)
+	assert.InDelta(t, 60, geo.SphericalAngle(p, q, r).F64(), 0.5)
+
+	// The order of a and b doesn't matter.
+	assert.Equal(t, geo.SphericalAngle(p, q, r), geo.SphericalAngle(p, r, q))
+
+	assert.Equal(t, geo.Degrees(0), geo.SphericalAngle(p, p, q))
+}
+
+func TestCrossedLine(t *testing.T) {
+	var (
+		// A line running North, so West is left, and East is right.
+		start = geo.LLA{Latitude: 0, Longitude: 0}
+		end   = geo.LLA{Latitude: 1, Longitude: 0}
+
+		west = geo.LLA{Latitude: 0.5, Longitude: -0.1}
+		east = geo.LLA{Latitude: 0.5, Longitude: 0.1}
+	)
+
+	crossed, direction := geo.CrossedLine(west, east, start, end)
+	assert.True(t, crossed)
+	assert.Equal(t, 1, direction)
+
+	crossed, direction = geo.CrossedLine(east, west, start, end)
+	assert.True(t, crossed)
+	assert.Equal(t, -1, direction)
+
+	// Going the long way around the end of the line doesn't cross it.
+	crossed, direction = geo.CrossedLine(
+		geo.LLA{Latitude: 2, Longitude: -0.1},
+		geo.LLA{Latitude: 2, Longitude: 0.1},
+		start, end,
+	)
+	assert.False(t, crossed)
+	assert.Equal(t, 0, direction)
+
+	// Moving along one side doesn't cross it either.
+	crossed, _ = geo.CrossedLine(west, geo.LLA{Latitude: 0.6, Longitude: -0.1}, start, end)
+	assert.False(t, crossed)
+
+	// Touching the line and going back to the same side is no crossing at
+	// all, on the way onto the line or on the way off of it.
+	on := geo.LLA{Latitude: 0.5, Longitude: 0}
+	crossed, direction = geo.CrossedLine(west, on, start, end)
+	assert.False(t, crossed)
+	assert.Equal(t, 0, direction)
+	crossed, direction = geo.CrossedLine(on, west, start, end)
+	assert.False(t, crossed)
+	assert.Equal(t, 0, direction)
+
+	// Going on to the other side isn't a crossing from the point on the
+	// line, but it is from the last point that wasn't.
+	crossed, _ = geo.CrossedLine(on, east, start, end)
+	assert.False(t, crossed)
+	crossed, direction = geo.CrossedLine(west, east, start, end)
+	assert.True(t, crossed)
+	assert.Equal(t, 1, direction)
+}
@@ -0,0 +1,71 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// GlobalGrid will return the center of every cell in a regular Latitude /
+// Longitude grid covering the whole globe, where each cell is cellDegrees
+// on a side. Cells are returned row by row, starting from the South-West
+// corner (-90, -180). If cellDegrees doesn't evenly divide the globe, the
+// last row and column are narrower, and the centers are of those narrower
+// cells.
+//
+// This is handy for building lookup tables over the whole Earth, but keep in
+// mind that cells get narrower (in Meters) as they get closer to the poles,
+// to the point that the cells touching the poles are slivers. A grid that's
+// equal in Degrees is not equal in area. A cellDegrees of 0 or less will
+// return nil.
+func GlobalGrid(cellDegrees Degrees) []LLA {
+	if cellDegrees <= 0 {
+		return nil
+	}
+
+	var (
+		cell = cellDegrees.F64()
+		rows = int(math.Ceil(180/cell - 1e-9))
+		cols = int(math.Ceil(360/cell - 1e-9))
+
+		centers = make([]LLA, 0, rows*cols)
+	)
+
+	for row := 0; row < rows; row++ {
+		var (
+			south = -90 + float64(row)*cell
+			north = math.Min(south+cell, 90)
+		)
+		for col := 0; col < cols; col++ {
+			var (
+				west = -180 + float64(col)*cell
+				east = math.Min(west+cell, 180)
+			)
+			centers = append(centers, LLA{
+				Latitude:  Degrees((south + north) / 2),
+				Longitude: Degrees((west + east) / 2),
+			})
+		}
+	}
+	return centers
+}
+
+// vim: foldmethod=marker
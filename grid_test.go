@@ -0,0 +1,33 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGlobalGrid(t *testing.T) {
+	assert.Equal(t, []geo.LLA{
+		{Latitude: -45, Longitude: -135},
+		{Latitude: -45, Longitude: -45},
+		{Latitude: -45, Longitude: 45},
+		{Latitude: -45, Longitude: 135},
+		{Latitude: 45, Longitude: -135},
+		{Latitude: 45, Longitude: -45},
+		{Latitude: 45, Longitude: 45},
+		{Latitude: 45, Longitude: 135},
+	}, geo.GlobalGrid(90))
+
+	assert.Len(t, geo.GlobalGrid(1), 180*360)
+	assert.Nil(t, geo.GlobalGrid(0))
+}
+
+func TestGlobalGridUneven(t *testing.T) {
+	// 100 Degrees leaves an 80 Degree row, and a 60 Degree column.
+	grid := geo.GlobalGrid(100)
+	assert.Len(t, grid, 2*4)
+	assert.Equal(t, geo.LLA{Latitude: -40, Longitude: -130}, grid[0])
+	assert.Equal(t, geo.LLA{Latitude: 50, Longitude: 150}, grid[7])
+}
@@ -45,7 +45,26 @@ func HaversineDistance(origin, position LLA) (Meters, error) {
 	if origin.Altitude != 0 || position.Altitude != 0 {
 		return 0, fmt.Errorf("geo.HaversineDistance: Altitude must be 0")
 	}
+	return haversine(origin, position), nil
+}
+
+// HaversineDistanceAllowEqualAltitude will return the same great-circle
+// distance along the surface of the Earth as HaversineDistance, but rather
+// than insisting on an Altitude of 0, this will allow any Altitude, so long as
+// both points are at the *same* Altitude. The Altitude is otherwise ignored;
+// this is still the distance along the surface, not at the Altitude.
+//
+// This will return an error if the provided geo.LLA structs have different
+// Altitudes.
+func HaversineDistanceAllowEqualAltitude(origin, position LLA) (Meters, error) {
+	if origin.Altitude != position.Altitude {
+		return 0, fmt.Errorf("geo.HaversineDistanceAllowEqualAltitude: Altitudes must be equal")
+	}
+	return haversine(origin, position), nil
+}
 
+// haversine will do the math for HaversineDistance, ignoring Altitude.
+func haversine(origin, position LLA) Meters {
 	var (
 		originLon = origin.Longitude.Radians().F64()
 		originLat = origin.Latitude.Radians().F64()
@@ -60,7 +79,43 @@ func HaversineDistance(origin, position LLA) (Meters, error) {
 	a := math.Pow(math.Sin(deltaLat/2), 2) + math.Cos(originLat)*math.Cos(positionLat)*math.Pow(math.Sin(deltaLon/2), 2)
 
 	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	return Meters(earthRadiusMeters * c), nil
+	return Meters(earthRadiusMeters * c)
+}
+
+// Inverse will solve the "inverse problem" between the two Lat/Lon points,
+// returning both the HaversineDistance and the InitialBearing from the origin
+// to the position in a single pass, rather than working out the same trig
+// twice by calling each on their own.
+//
+// Just like HaversineDistance, this will return an error if either of the
+// provided geo.LLA structs have an Altitude other than 0.
+func Inverse(origin, position LLA) (Meters, Degrees, error) {
+	if origin.Altitude != 0 || position.Altitude != 0 {
+		return 0, 0, fmt.Errorf("geo.Inverse: Altitude must be 0")
+	}
+
+	var (
+		originLat   = origin.Latitude.Radians().F64()
+		positionLat = position.Latitude.Radians().F64()
+
+		deltaLon = (position.Longitude - origin.Longitude).Radians().F64()
+		deltaLat = positionLat - originLat
+
+		sinOriginLat   = math.Sin(originLat)
+		cosOriginLat   = math.Cos(originLat)
+		sinPositionLat = math.Sin(positionLat)
+		cosPositionLat = math.Cos(positionLat)
+	)
+
+	a := math.Pow(math.Sin(deltaLat/2), 2) + cosOriginLat*cosPositionLat*math.Pow(math.Sin(deltaLon/2), 2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	bearing := Radians(math.Atan2(
+		math.Sin(deltaLon)*cosPositionLat,
+		cosOriginLat*sinPositionLat-sinOriginLat*cosPositionLat*math.Cos(deltaLon),
+	)).Degrees()
+
+	return Meters(earthRadiusMeters * c), normalizeBearing(bearing), nil
 }
 
 // vim: foldmethod=marker
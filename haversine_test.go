@@ -57,3 +57,39 @@ func BenchmarkDistance(b *testing.B) {
 		_, _ = geo.HaversineDistance(from, to)
 	}
 }
+
+func TestInverse(t *testing.T) {
+	for _, input := range testsCases {
+		meters, bearing, err := geo.Inverse(input.from, input.to)
+		assert.NoError(t, err)
+
+		expectedMeters, err := geo.HaversineDistance(input.from, input.to)
+		assert.NoError(t, err)
+
+		assert.InEpsilon(t, expectedMeters.F64(), meters.F64(), 1e-12)
+		assert.InEpsilon(t, geo.InitialBearing(input.from, input.to).F64(), bearing.F64(), 1e-12)
+	}
+
+	_, _, err := geo.Inverse(
+		geo.LLA{Latitude: 51.510357, Longitude: -0.116773},
+		geo.LLA{Latitude: 51.510357, Longitude: -0.116773, Altitude: 10},
+	)
+	assert.Error(t, err)
+}
+
+func TestDistanceAllowEqualAltitude(t *testing.T) {
+	for _, input := range testsCases {
+		from := input.from
+		from.Altitude = 1000
+		to := input.to
+		to.Altitude = 1000
+
+		meters, err := geo.HaversineDistanceAllowEqualAltitude(from, to)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, input.expectedMeters, meters.F64(), 1e-6)
+
+		to.Altitude = 1001
+		_, err = geo.HaversineDistanceAllowEqualAltitude(from, to)
+		assert.Error(t, err)
+	}
+}
@@ -0,0 +1,144 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// InterpolatedConverter is a fast, approximate, stand-in for the LLAToXYZ of
+// a CoordinateSystem, for when the trig of the real thing is too much for an
+// embedded or real-time target.
+type InterpolatedConverter interface {
+	// LLAToXYZ will return the approximate XYZ of the LLA.
+	LLAToXYZ(LLA) XYZ
+}
+
+// NewInterpolatedConverter will precompute a grid of rows by cols points of
+// the CoordinateSystem's LLAToXYZ covering the BoundingBox, returning an
+// InterpolatedConverter that bilinearly interpolates between them. Both the
+// point on the surface and the direction of Up are interpolated, so the
+// Altitude is handled exactly along the (interpolated) surface normal.
+//
+// The error comes from the surface curving away from the straight lines
+// between grid points, and is roughly R * θ² / 8, where R is the radius of
+// the Earth, and θ is the size of a grid cell in Radians. That's about 2.5m
+// for cells of a tenth of a Degree, and 2.5cm for cells of a hundredth of a
+// Degree. Each grid point takes up 48 bytes, so a 1 Degree box at a
+// hundredth of a Degree is about half a megabyte.
+//
+// LLAs outside of the BoundingBox are extrapolated from the nearest edge of
+// the grid, which gets bad quickly. At least 2 rows and 2 cols are needed,
+// and the BoundingBox must have some area to it, or an error is returned.
+func NewInterpolatedConverter(cs CoordinateSystem, box BoundingBox, rows, cols int) (InterpolatedConverter, error) {
+	if rows < 2 || cols < 2 {
+		return nil, fmt.Errorf("geo.NewInterpolatedConverter: at least 2 rows and cols are needed")
+	}
+
+	width := box.East - box.West
+	if width < 0 {
+		width += 360
+	}
+	height := box.North - box.South
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("geo.NewInterpolatedConverter: BoundingBox has no area")
+	}
+
+	c := interpolatedConverter{
+		box:     box,
+		width:   width,
+		rows:    rows,
+		cols:    cols,
+		dLat:    height / Degrees(rows-1),
+		dLon:    width / Degrees(cols-1),
+		surface: make([]vector, rows*cols),
+		up:      make([]vector, rows*cols),
+	}
+
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			var (
+				lla = LLA{
+					Latitude:  box.South + Degrees(row)*c.dLat,
+					Longitude: box.West + Degrees(col)*c.dLon,
+				}
+				surface = cs.LLAToXYZ(lla).vector()
+				above   = cs.LLAToXYZ(LLA{Latitude: lla.Latitude, Longitude: lla.Longitude, Altitude: 1}).vector()
+			)
+			c.surface[row*cols+col] = surface
+			c.up[row*cols+col] = above.sub(surface)
+		}
+	}
+	return c, nil
+}
+
+type interpolatedConverter struct {
+	box        BoundingBox
+	width      Degrees
+	rows, cols int
+	dLat, dLon Degrees
+
+	surface []vector
+	up      []vector
+}
+
+// cell will return the index of the cell along an axis, along with how far
+// into the cell the value is, clamping to the cells along the edge.
+func (c interpolatedConverter) cell(value float64, cells int) (int, float64) {
+	i := int(math.Floor(value))
+	if i < 0 {
+		i = 0
+	}
+	if i > cells-2 {
+		i = cells - 2
+	}
+	return i, value - float64(i)
+}
+
+func (c interpolatedConverter) LLAToXYZ(lla LLA) XYZ {
+	var (
+		// Measure the Longitude from the middle of the box, so that points
+		// outside of it are closest to the nearer edge, rather than wrapping
+		// around to the far one.
+		half = c.width / 2
+		lon  = normalizeLongitude(lla.Longitude-(c.box.West+half)) + half
+
+		row, v = c.cell(((lla.Latitude - c.box.South) / c.dLat).F64(), c.rows)
+		col, u = c.cell((lon / c.dLon).F64(), c.cols)
+
+		i00 = row*c.cols + col
+		i01 = i00 + 1
+		i10 = i00 + c.cols
+		i11 = i10 + 1
+
+		lerp = func(grid []vector) vector {
+			return grid[i00].scale((1 - u) * (1 - v)).
+				add(grid[i01].scale(u * (1 - v))).
+				add(grid[i10].scale((1 - u) * v)).
+				add(grid[i11].scale(u * v))
+		}
+	)
+
+	return lerp(c.surface).add(lerp(c.up).unit().scale(lla.Altitude.F64())).xyz()
+}
+
+// vim: foldmethod=marker
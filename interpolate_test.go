@@ -0,0 +1,81 @@
+package geo_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolatedConverter(t *testing.T) {
+	var (
+		wgs = geo.WGS84()
+		box = geo.BoundingBox{South: 38, West: -78, North: 39, East: -77}
+		r   = rand.New(rand.NewSource(42))
+	)
+
+	// A hundredth of a Degree, which is documented to be good to a few cm.
+	converter, err := geo.NewInterpolatedConverter(wgs, box, 101, 101)
+	assert.NoError(t, err)
+
+	for i := 0; i < 1000; i++ {
+		lla := geo.RandomLLAInBox(r, box)
+		lla.Altitude = geo.Meters(r.Float64() * 10000)
+		assert.Less(t, distance(wgs.LLAToXYZ(lla), converter.LLAToXYZ(lla)), 0.05)
+	}
+
+	// The grid points themselves are exact.
+	corner := geo.LLA{Latitude: 38, Longitude: -78, Altitude: 100}
+	assert.Less(t, distance(wgs.LLAToXYZ(corner), converter.LLAToXYZ(corner)), 1e-6)
+}
+
+func TestInterpolatedConverterAntiMeridian(t *testing.T) {
+	var (
+		wgs = geo.WGS84()
+		box = geo.BoundingBox{South: -10, West: 179.5, North: -9.5, East: -179.5}
+	)
+
+	converter, err := geo.NewInterpolatedConverter(wgs, box, 51, 101)
+	assert.NoError(t, err)
+
+	for _, lla := range []geo.LLA{
+		{Latitude: -9.75, Longitude: 179.9},
+		{Latitude: -9.75, Longitude: -180},
+		{Latitude: -9.6, Longitude: -179.7, Altitude: 500},
+	} {
+		assert.Less(t, distance(wgs.LLAToXYZ(lla), converter.LLAToXYZ(lla)), 0.05)
+	}
+}
+
+func TestInterpolatedConverterErrors(t *testing.T) {
+	wgs := geo.WGS84()
+
+	_, err := geo.NewInterpolatedConverter(wgs, geo.BoundingBox{South: 0, West: 0, North: 1, East: 1}, 1, 10)
+	assert.Error(t, err)
+
+	_, err = geo.NewInterpolatedConverter(wgs, geo.BoundingBox{South: 0, West: 0, North: 0, East: 1}, 10, 10)
+	assert.Error(t, err)
+}
+
+func TestInterpolatedConverterOutside(t *testing.T) {
+	var (
+		wgs = geo.WGS84()
+		box = geo.BoundingBox{South: 38, West: -78, North: 39, East: -77}
+	)
+
+	converter, err := geo.NewInterpolatedConverter(wgs, box, 11, 11)
+	assert.NoError(t, err)
+
+	// Just outside of each edge is extrapolated from that edge, which is a
+	// little worse than inside of the box, but not by much.
+	for _, lla := range []geo.LLA{
+		{Latitude: 38.5, Longitude: -78.01},
+		{Latitude: 38.5, Longitude: -76.99},
+		{Latitude: 37.99, Longitude: -77.5},
+		{Latitude: 39.01, Longitude: -77.5},
+	} {
+		assert.Less(t, distance(wgs.LLAToXYZ(lla), converter.LLAToXYZ(lla)), 5.0, lla)
+	}
+}
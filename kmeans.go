@@ -0,0 +1,116 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+)
+
+// KMeansSpherical will group the points into k clusters, returning the
+// centroid of each cluster, and the index of the cluster each point was put
+// into (in the same order as the points).
+//
+// The clustering is done on unit vectors pointing at each point, with each
+// centroid (the mean of the vectors in the cluster) pushed back out onto the
+// sphere after every iteration, so clusters that straddle the anti-meridian
+// or sit on top of a pole work just as well as any other. The initial
+// centroids are picked by starting with the first point, and then repeatedly
+// adding the point furthest from any centroid picked so far, so the results
+// are always the same for the same input.
+//
+// This runs until no point changes clusters, or for maxIter iterations,
+// whichever comes first. This treats the Earth as a sphere, ignores Altitude,
+// and the returned centroids have an Altitude of 0. If k is less than 1, or
+// more than the number of points, an error is returned.
+func KMeansSpherical(points []LLA, k int, maxIter int) (centroids []LLA, labels []int, err error) {
+	if k < 1 {
+		return nil, nil, fmt.Errorf("geo.KMeansSpherical: k must be at least 1")
+	}
+	if k > len(points) {
+		return nil, nil, fmt.Errorf("geo.KMeansSpherical: k is larger than the number of points")
+	}
+
+	vectors := make([]vector, len(points))
+	for i, point := range points {
+		vectors[i] = unitVector(point)
+	}
+
+	// nearest will return the index of the center closest to v, and how
+	// close it is (as a dot product, so bigger is closer).
+	nearest := func(centers []vector, v vector) (int, float64) {
+		best, closest := 0, centers[0].dot(v)
+		for i, center := range centers[1:] {
+			if d := center.dot(v); d > closest {
+				best, closest = i+1, d
+			}
+		}
+		return best, closest
+	}
+
+	centers := []vector{vectors[0]}
+	for len(centers) < k {
+		furthest, furthestDot := 0, 2.0
+		for i, v := range vectors {
+			if _, d := nearest(centers, v); d < furthestDot {
+				furthest, furthestDot = i, d
+			}
+		}
+		centers = append(centers, vectors[furthest])
+	}
+
+	labels = make([]int, len(points))
+	for i, v := range vectors {
+		labels[i], _ = nearest(centers, v)
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		sums := make([]vector, k)
+		for i, v := range vectors {
+			sums[labels[i]] = sums[labels[i]].add(v)
+		}
+		for i, sum := range sums {
+			// A cluster that's lost all of its points (or whose points
+			// cancel each other out) keeps the centroid it had.
+			if sum.norm() != 0 {
+				centers[i] = sum.unit()
+			}
+		}
+
+		changed := false
+		for i, v := range vectors {
+			if label, _ := nearest(centers, v); label != labels[i] {
+				labels[i] = label
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	centroids = make([]LLA, k)
+	for i, center := range centers {
+		centroids[i] = center.lla()
+	}
+	return centroids, labels, nil
+}
+
+// vim: foldmethod=marker
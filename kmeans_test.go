@@ -0,0 +1,51 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKMeansSpherical(t *testing.T) {
+	points := []geo.LLA{
+		// Straddling the anti-meridian.
+		{Latitude: 10.1, Longitude: 179.9},
+		{Latitude: 9.9, Longitude: -179.9},
+		{Latitude: 10.1, Longitude: -179.9},
+		{Latitude: 9.9, Longitude: 179.9},
+
+		// Way off elsewhere.
+		{Latitude: -40.1, Longitude: 20},
+		{Latitude: -39.9, Longitude: 20},
+		{Latitude: -40, Longitude: 20.1},
+		{Latitude: -40, Longitude: 19.9},
+	}
+
+	centroids, labels, err := geo.KMeansSpherical(points, 2, 100)
+	assert.NoError(t, err)
+	assert.Len(t, centroids, 2)
+	assert.Equal(t, []int{0, 0, 0, 0, 1, 1, 1, 1}, labels)
+
+	// Averaging across the anti-meridian lands on it, not at 0.
+	assert.True(t, centroids[0].ApproxEqual(geo.LLA{Latitude: 10, Longitude: 180}, 1e-3, 0))
+	assert.True(t, centroids[1].ApproxEqual(geo.LLA{Latitude: -40, Longitude: 20}, 1e-3, 0))
+}
+
+func TestKMeansSphericalErrors(t *testing.T) {
+	points := []geo.LLA{{Latitude: 1}, {Latitude: 2}}
+
+	_, _, err := geo.KMeansSpherical(points, 3, 10)
+	assert.Error(t, err)
+
+	_, _, err = geo.KMeansSpherical(points, 0, 10)
+	assert.Error(t, err)
+
+	// One cluster per point puts every point in its own cluster.
+	centroids, labels, err := geo.KMeansSpherical(points, 2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, labels)
+	assert.InDelta(t, 1, centroids[0].Latitude.F64(), 1e-9)
+	assert.InDelta(t, 2, centroids[1].Latitude.F64(), 1e-9)
+}
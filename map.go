@@ -0,0 +1,58 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+)
+
+// ToMap will return the LLA as a map, with the Latitude (in Degrees) under
+// "lat", the Longitude (in Degrees) under "lon" and the Altitude (in Meters)
+// under "alt". This is handy for schemaless pipelines, such as building up a
+// generic JSON (or BSON) document.
+func (l LLA) ToMap() map[string]float64 {
+	return map[string]float64{
+		"lat": l.Latitude.F64(),
+		"lon": l.Longitude.F64(),
+		"alt": l.Altitude.F64(),
+	}
+}
+
+// LLAFromMap will return the LLA in a map written by LLA.ToMap. The "lat"
+// and "lon" keys must both be present, but if "alt" is missing, the Altitude
+// is 0. Any other keys are ignored.
+func LLAFromMap(m map[string]float64) (LLA, error) {
+	lat, ok := m["lat"]
+	if !ok {
+		return LLA{}, fmt.Errorf("geo.LLAFromMap: missing \"lat\"")
+	}
+	lon, ok := m["lon"]
+	if !ok {
+		return LLA{}, fmt.Errorf("geo.LLAFromMap: missing \"lon\"")
+	}
+	return LLA{
+		Latitude:  Degrees(lat),
+		Longitude: Degrees(lon),
+		Altitude:  Meters(m["alt"]),
+	}, nil
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,32 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLLAMapRoundTrip(t *testing.T) {
+	lla := geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30}
+
+	m := lla.ToMap()
+	assert.Equal(t, map[string]float64{"lat": 38.897957, "lon": -77.036560, "alt": 30}, m)
+
+	lla1, err := geo.LLAFromMap(m)
+	assert.NoError(t, err)
+	assert.Equal(t, lla, lla1)
+}
+
+func TestLLAFromMap(t *testing.T) {
+	lla, err := geo.LLAFromMap(map[string]float64{"lat": 10, "lon": 20})
+	assert.NoError(t, err)
+	assert.Equal(t, geo.LLA{Latitude: 10, Longitude: 20}, lla)
+
+	_, err = geo.LLAFromMap(map[string]float64{"lon": 20})
+	assert.Error(t, err)
+
+	_, err = geo.LLAFromMap(map[string]float64{"lat": 10, "alt": 20})
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// multilaterateIterations is the most Gauss-Newton steps Multilaterate will
+// take before giving up.
+const multilaterateIterations = 50
+
+// multilaterateTolerance is how small (in Meters) a Gauss-Newton step needs
+// to be for Multilaterate to consider the solution converged.
+const multilaterateTolerance = 1e-6
+
+// solve3 will solve the 3x3 system of linear equations m * x = b, returning
+// false if the matrix is singular (or close enough to it).
+func solve3(m [3][3]float64, b [3]float64) ([3]float64, bool) {
+	det := func(m [3][3]float64) float64 {
+		return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	}
+
+	var (
+		x [3]float64
+		d = det(m)
+	)
+
+	scale := 0.0
+	for _, row := range m {
+		for _, v := range row {
+			scale = math.Max(scale, math.Abs(v))
+		}
+	}
+	if scale == 0 || math.Abs(d) <= 1e-12*scale*scale*scale {
+		return x, false
+	}
+
+	// Cramer's rule -- swap each column out for b in turn.
+	for i := range x {
+		mi := m
+		for row := range mi {
+			mi[row][i] = b[row]
+		}
+		x[i] = det(mi) / d
+	}
+	return x, true
+}
+
+// Multilaterate will return the point that best fits the measured ranges
+// (straight-line distances, in Meters) to each of the anchors, in the least
+// squares sense. This is the classic way to find a position from the ranges
+// to known points, as is done with UWB beacons (or, with a clock bias thrown
+// in, GPS).
+//
+// The solution is found with Gauss-Newton iteration in WGS84 XYZ space,
+// starting from a point above the middle of the anchors (by the mean of the
+// ranges). With only three anchors, there are two points that fit the ranges
+// (mirrored through the plane of the anchors), and the one found will
+// generally be the upper one, which is usually what's wanted for anchors on
+// the ground.
+//
+// At least three anchors (and exactly one range per anchor) are needed, and
+// an error is returned if the iteration doesn't converge.
+func Multilaterate(anchors []LLA, ranges []Meters) (LLA, error) {
+	if len(anchors) != len(ranges) {
+		return LLA{}, fmt.Errorf("geo.Multilaterate: %d anchors but %d ranges", len(anchors), len(ranges))
+	}
+	if len(anchors) < 3 {
+		return LLA{}, fmt.Errorf("geo.Multilaterate: at least 3 anchors are needed")
+	}
+
+	var (
+		wgs    = WGS84()
+		points = make([]vector, len(anchors))
+		x      vector
+	)
+
+	var spread float64
+	for i, anchor := range anchors {
+		points[i] = wgs.LLAToXYZ(anchor).vector()
+		x = x.add(points[i])
+		spread += ranges[i].F64()
+	}
+	x = x.scale(1 / float64(len(points)))
+	x = x.add(x.unit().scale(spread / float64(len(points))))
+
+	for iteration := 0; iteration < multilaterateIterations; iteration++ {
+		var (
+			jtj [3][3]float64
+			jtr [3]float64
+		)
+
+		for i, point := range points {
+			var (
+				delta    = x.sub(point)
+				distance = delta.norm()
+			)
+			if distance == 0 {
+				continue
+			}
+
+			var (
+				g        = delta.scale(1 / distance)
+				row      = [3]float64{g.x, g.y, g.z}
+				residual = distance - ranges[i].F64()
+			)
+			for a := 0; a < 3; a++ {
+				for b := 0; b < 3; b++ {
+					jtj[a][b] += row[a] * row[b]
+				}
+				jtr[a] -= row[a] * residual
+			}
+		}
+
+		step, ok := solve3(jtj, jtr)
+		if !ok {
+			return LLA{}, fmt.Errorf("geo.Multilaterate: anchors are degenerate")
+		}
+
+		s := vector{x: step[0], y: step[1], z: step[2]}
+		x = x.add(s)
+		if s.norm() < multilaterateTolerance {
+			return XYZToLLAWithOptions(x.xyz(), DefaultConvergenceOptions), nil
+		}
+	}
+
+	return LLA{}, fmt.Errorf("geo.Multilaterate: failed to converge")
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,59 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ranges(cs geo.CoordinateSystem, target geo.LLA, anchors []geo.LLA) []geo.Meters {
+	measured := make([]geo.Meters, len(anchors))
+	for i, anchor := range anchors {
+		measured[i] = geo.ChordDistance(cs, target, anchor)
+	}
+	return measured
+}
+
+func TestMultilaterate(t *testing.T) {
+	var (
+		wgs     = geo.WGS84()
+		target  = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 25}
+		anchors = []geo.LLA{
+			{Latitude: 38.90, Longitude: -77.04, Altitude: 10},
+			{Latitude: 38.89, Longitude: -77.03, Altitude: 20},
+			{Latitude: 38.91, Longitude: -77.02, Altitude: 15},
+		}
+	)
+
+	found, err := geo.Multilaterate(anchors, ranges(wgs, target, anchors))
+	assert.NoError(t, err)
+	assert.True(t, found.ApproxEqual(target, 1e-9, 1e-3))
+
+	// An extra anchor (off the plane of the others) will do no harm.
+	anchors = append(anchors, geo.LLA{Latitude: 38.895, Longitude: -77.035, Altitude: 300})
+	found, err = geo.Multilaterate(anchors, ranges(wgs, target, anchors))
+	assert.NoError(t, err)
+	assert.True(t, found.ApproxEqual(target, 1e-9, 1e-3))
+}
+
+func TestMultilaterateErrors(t *testing.T) {
+	anchors := []geo.LLA{
+		{Latitude: 38.90, Longitude: -77.04},
+		{Latitude: 38.89, Longitude: -77.03},
+	}
+
+	_, err := geo.Multilaterate(anchors, []geo.Meters{100, 200})
+	assert.Error(t, err)
+
+	_, err = geo.Multilaterate(anchors, []geo.Meters{100})
+	assert.Error(t, err)
+
+	// All the anchors in the same place.
+	_, err = geo.Multilaterate(
+		[]geo.LLA{anchors[0], anchors[0], anchors[0]},
+		[]geo.Meters{100, 100, 100},
+	)
+	assert.Error(t, err)
+}
@@ -0,0 +1,323 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// Polygon is a single ring of points on the surface of the Earth.
+//
+// The ring is implicitly closed -- the last point is connected back to the
+// first one. Having the last point repeat the first point (as GeoJSON does)
+// is fine too, and won't change any of the results.
+type Polygon []LLA
+
+// normalizeRadians will wrap the angle to be within [-π, π).
+func normalizeRadians(r float64) float64 {
+	r = math.Mod(r+math.Pi, 2*math.Pi)
+	if r < 0 {
+		r += 2 * math.Pi
+	}
+	return r - math.Pi
+}
+
+// signedArea will return the area of the ring on the unit sphere, with the
+// sign determined by the ring's winding.
+func (p Polygon) signedArea() float64 {
+	var sum float64
+	for i := range p {
+		var (
+			a = p[i]
+			b = p[(i+1)%len(p)]
+
+			lonA = a.Longitude.Radians().F64()
+			lonB = b.Longitude.Radians().F64()
+			latA = a.Latitude.Radians().F64()
+			latB = b.Latitude.Radians().F64()
+		)
+		sum += normalizeRadians(lonB-lonA) * (2 + math.Sin(latA) + math.Sin(latB))
+	}
+	return sum / 2
+}
+
+// Area will return the area of the Polygon on the surface of the Earth. This
+// treats the Earth as a sphere (with the same radius HaversineDistance uses),
+// and ignores Altitude.
+//
+// Edges crossing the anti-meridian are handled, so long as no single edge
+// spans more than 180 degrees of Longitude.
+func (p Polygon) Area() SquareMeters {
+	return SquareMeters(math.Abs(p.signedArea()) * earthRadiusMeters * earthRadiusMeters)
+}
+
+// IsCCW will return true if the ring of the Polygon winds counter-clockwise
+// (as seen from above, looking down at the surface), which is the order
+// GeoJSON expects for an outer ring. A Polygon with no area (such as one
+// with all of its points along a line) doesn't wind either way, and isn't
+// counter-clockwise.
+func (p Polygon) IsCCW() bool {
+	return p.signedArea() < 0
+}
+
+// EnsureCCW will return a copy of the Polygon that winds counter-clockwise,
+// reversing the order of the points if the Polygon winds clockwise. This is
+// handy for normalizing input (such as a shapefile, which winds outer rings
+// clockwise) before doing anything that cares about the winding.
+func (p Polygon) EnsureCCW() Polygon {
+	ccw := make(Polygon, len(p))
+	copy(ccw, p)
+	if p.signedArea() > 0 {
+		for i, j := 0, len(ccw)-1; i < j; i, j = i+1, j-1 {
+			ccw[i], ccw[j] = ccw[j], ccw[i]
+		}
+	}
+	return ccw
+}
+
+// Contains will return true if the point is inside the Polygon.
+//
+// This is done by counting edge crossings on a ray out from the point in
+// Latitude / Longitude space. The Longitudes of the ring are unwrapped to be
+// continuous (so that Polygons that straddle the anti-meridian work), which
+// means that Polygons that contain a pole are not supported. Edges are
+// treated as straight lines in Latitude / Longitude rather than great
+// circles, which matters for very large Polygons. Altitude is ignored.
+func (p Polygon) Contains(point LLA) bool {
+	if len(p) == 0 {
+		return false
+	}
+
+	var (
+		ring = UnwrapLongitudes(p)
+
+		minX = ring[0].Longitude
+		maxX = minX
+	)
+
+	for _, vertex := range ring {
+		if vertex.Longitude < minX {
+			minX = vertex.Longitude
+		}
+		if vertex.Longitude > maxX {
+			maxX = vertex.Longitude
+		}
+	}
+
+	var (
+		inside = false
+		mid    = (minX + maxX) / 2
+		px     = (mid + normalizeLongitude(point.Longitude-mid)).F64()
+		py     = point.Latitude.F64()
+	)
+
+	for i := range p {
+		var (
+			j = (i + 1) % len(p)
+
+			ax = ring[i].Longitude.F64()
+			ay = ring[i].Latitude.F64()
+			bx = ring[j].Longitude.F64()
+			by = ring[j].Latitude.F64()
+		)
+
+		if (ay > py) != (by > py) && px < (bx-ax)*(py-ay)/(by-ay)+ax {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DistanceToBoundary will return the distance from the point to the closest
+// point along any edge of the Polygon, where the edges are great circles.
+// The distance is positive if the point is outside of the Polygon, and
+// negative if it's inside (as determined by Polygon.Contains), so this can
+// be used to tell how far outside (or how deep inside) a fence the point is.
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude. An empty Polygon has no boundary, and a distance of 0.
+func (p Polygon) DistanceToBoundary(point LLA) Meters {
+	if len(p) == 0 {
+		return 0
+	}
+
+	var (
+		v       = unitVector(point)
+		closest = math.Inf(1)
+	)
+
+	for i := range p {
+		var (
+			a = unitVector(p[i])
+			b = unitVector(p[(i+1)%len(p)])
+		)
+		closest = math.Min(closest, angleToSegment(v, a, b).F64())
+	}
+
+	distance := Meters(closest * earthRadiusMeters)
+	if p.Contains(point) {
+		return -distance
+	}
+	return distance
+}
+
+// DistanceToPolygon will return the distance from the point to the Polygon,
+// which is 0 if the point is inside of it, or the distance to the closest
+// point along any edge if it's outside. This is Polygon.DistanceToBoundary,
+// without caring how deep inside the point is.
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude.
+func DistanceToPolygon(point LLA, poly Polygon) Meters {
+	return Meters(math.Max(0, poly.DistanceToBoundary(point).F64()))
+}
+
+// IsSimple will return true if no two edges of the Polygon cross (or touch)
+// each other, other than neighboring edges meeting at their shared vertex.
+// A Polygon that isn't simple (like a bow-tie) doesn't have a well defined
+// inside, and the results of Area and Contains won't mean much.
+//
+// Edges are treated as great circles, on a sphere, and Altitude is ignored.
+// A closing point that repeats the first point is fine, but Polygons with
+// fewer than 3 distinct vertices are not simple.
+func (p Polygon) IsSimple() bool {
+	ring := p
+	if len(ring) > 1 && ring[0].Latitude == ring[len(ring)-1].Latitude &&
+		ring[0].Longitude == ring[len(ring)-1].Longitude {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return false
+	}
+
+	vertices := make([]vector, len(ring))
+	for i, vertex := range ring {
+		vertices[i] = unitVector(vertex)
+	}
+
+	for i := range vertices {
+		var (
+			a1 = vertices[i]
+			a2 = vertices[(i+1)%len(vertices)]
+		)
+		if a1.angle(a2) == 0 {
+			return false
+		}
+
+		for j := i + 2; j < len(vertices); j++ {
+			if i == 0 && j == len(vertices)-1 {
+				// The last edge shares the first vertex with the first.
+				continue
+			}
+			if _, ok := segmentsIntersect(a1, a2, vertices[j], vertices[(j+1)%len(vertices)]); ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Centroid will return the area-weighted center of the Polygon, which is
+// not the same as the average of the vertices unless the vertices happen to
+// be evenly spread out around the shape.
+//
+// This is done by projecting the ring onto the WGS84 tangent plane at the
+// first vertex, finding the centroid of the flat polygon, and then projecting
+// that point back. This is only a good answer for Polygons small enough that
+// the tangent plane is a good fit to the Earth. The Altitude returned is the
+// Altitude of the first vertex. If the Polygon has no area at all (say, all
+// the points are in a line), the plain average of the vertices is used.
+func (p Polygon) Centroid() LLA {
+	if len(p) == 0 {
+		return LLA{}
+	}
+
+	var (
+		wgs = WGS84()
+		ref = LLA{Latitude: p[0].Latitude, Longitude: p[0].Longitude}
+
+		area, cx, cy float64
+		mx, my       float64
+	)
+
+	for i := range p {
+		var (
+			a = wgs.LLAToENU(ref, LLA{Latitude: p[i].Latitude, Longitude: p[i].Longitude})
+			b = wgs.LLAToENU(ref, LLA{
+				Latitude:  p[(i+1)%len(p)].Latitude,
+				Longitude: p[(i+1)%len(p)].Longitude,
+			})
+
+			cross = a.East.F64()*b.North.F64() - b.East.F64()*a.North.F64()
+		)
+		area += cross
+		cx += (a.East.F64() + b.East.F64()) * cross
+		cy += (a.North.F64() + b.North.F64()) * cross
+		mx += a.East.F64()
+		my += a.North.F64()
+	}
+
+	var center ENU
+	if math.Abs(area) < 1e-9 {
+		center = ENU{East: Meters(mx / float64(len(p))), North: Meters(my / float64(len(p)))}
+	} else {
+		center = ENU{East: Meters(cx / (3 * area)), North: Meters(cy / (3 * area))}
+	}
+
+	centroid := OffsetLLA(wgs, ref, center)
+	centroid.Altitude = p[0].Altitude
+	return centroid
+}
+
+// MultiRingPolygon is a Polygon that may have holes cut out of it, which is
+// to say, it's made up of an Outer ring, and any number of interior rings.
+// The Holes are expected to be entirely inside the Outer ring, and not to
+// overlap each other.
+type MultiRingPolygon struct {
+	Outer Polygon
+	Holes []Polygon
+}
+
+// Area will return the area of the Outer ring, less the area of each of the
+// Holes.
+func (p MultiRingPolygon) Area() SquareMeters {
+	area := p.Outer.Area()
+	for _, hole := range p.Holes {
+		area -= hole.Area()
+	}
+	return area
+}
+
+// Contains will return true if the point is inside the Outer ring, and not
+// inside any of the Holes.
+func (p MultiRingPolygon) Contains(point LLA) bool {
+	if !p.Outer.Contains(point) {
+		return false
+	}
+	for _, hole := range p.Holes {
+		if hole.Contains(point) {
+			return false
+		}
+	}
+	return true
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,183 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// boxArea is the area of a Latitude / Longitude box on the sphere used by
+// Polygon.Area.
+func boxArea(south, west, north, east float64) float64 {
+	r := 6371000.0
+	return r * r * geo.Degrees(east-west).Radians().F64() *
+		(math.Sin(geo.Degrees(north).Radians().F64()) - math.Sin(geo.Degrees(south).Radians().F64()))
+}
+
+func box(south, west, north, east geo.Degrees) geo.Polygon {
+	return geo.Polygon{
+		{Latitude: south, Longitude: west},
+		{Latitude: south, Longitude: east},
+		{Latitude: north, Longitude: east},
+		{Latitude: north, Longitude: west},
+	}
+}
+
+func TestPolygonArea(t *testing.T) {
+	square := box(0, 0, 1, 1)
+	assert.InEpsilon(t, boxArea(0, 0, 1, 1), square.Area().F64(), 1e-9)
+
+	closed := append(box(0, 0, 1, 1), geo.LLA{Latitude: 0, Longitude: 0})
+	assert.InEpsilon(t, boxArea(0, 0, 1, 1), closed.Area().F64(), 1e-9)
+
+	dateLine := box(10, 179, 11, -179)
+	assert.InEpsilon(t, boxArea(10, 179, 11, 181), dateLine.Area().F64(), 1e-9)
+}
+
+func TestPolygonContains(t *testing.T) {
+	square := box(0, 0, 1, 1)
+	assert.True(t, square.Contains(geo.LLA{Latitude: 0.5, Longitude: 0.5}))
+	assert.False(t, square.Contains(geo.LLA{Latitude: 1.5, Longitude: 0.5}))
+	assert.False(t, square.Contains(geo.LLA{Latitude: 0.5, Longitude: -0.5}))
+
+	dateLine := box(10, 179, 11, -179)
+	assert.True(t, dateLine.Contains(geo.LLA{Latitude: 10.5, Longitude: 180}))
+	assert.True(t, dateLine.Contains(geo.LLA{Latitude: 10.5, Longitude: -179.5}))
+	assert.False(t, dateLine.Contains(geo.LLA{Latitude: 10.5, Longitude: 0}))
+}
+
+func TestMultiRingPolygon(t *testing.T) {
+	polygon := geo.MultiRingPolygon{
+		Outer: box(0, 0, 1, 1),
+		Holes: []geo.Polygon{box(0.25, 0.25, 0.75, 0.75)},
+	}
+
+	assert.InEpsilon(t,
+		polygon.Outer.Area().F64()-polygon.Holes[0].Area().F64(),
+		polygon.Area().F64(),
+		1e-9,
+	)
+	assert.InEpsilon(t,
+		boxArea(0, 0, 1, 1)-boxArea(0.25, 0.25, 0.75, 0.75),
+		polygon.Area().F64(),
+		1e-9,
+	)
+
+	assert.True(t, polygon.Contains(geo.LLA{Latitude: 0.1, Longitude: 0.1}))
+	assert.False(t, polygon.Contains(geo.LLA{Latitude: 0.5, Longitude: 0.5}))
+	assert.False(t, polygon.Contains(geo.LLA{Latitude: 2, Longitude: 2}))
+}
+
+func TestPolygonDistanceToBoundary(t *testing.T) {
+	var (
+		square     = box(0, 0, 1, 1)
+		halfDegree = geo.Degrees(0.5).Radians().F64() * 6371000
+	)
+
+	// South of the box, and the bottom edge is along the Equator.
+	distance := square.DistanceToBoundary(geo.LLA{Latitude: -0.5, Longitude: 0.5})
+	assert.InEpsilon(t, halfDegree, distance.F64(), 1e-9)
+
+	// Off of the South-West corner, the corner is the closest point.
+	corner := geo.LLA{Latitude: -0.5, Longitude: -0.5}
+	expected, err := geo.HaversineDistance(corner, geo.LLA{})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, expected.F64(), square.DistanceToBoundary(corner).F64(), 1e-9)
+
+	// Inside is negative.
+	distance = square.DistanceToBoundary(geo.LLA{Latitude: 0.5, Longitude: 0.5})
+	assert.Less(t, distance.F64(), 0.0)
+	assert.InEpsilon(t, -halfDegree, distance.F64(), 1e-3)
+
+	assert.Equal(t, geo.Meters(0), geo.Polygon{}.DistanceToBoundary(corner))
+}
+
+func TestPolygonEnsureCCW(t *testing.T) {
+	ccw := box(0, 0, 1, 1)
+	assert.True(t, ccw.IsCCW())
+	assert.Equal(t, ccw, ccw.EnsureCCW())
+
+	cw := geo.Polygon{ccw[3], ccw[2], ccw[1], ccw[0]}
+	assert.False(t, cw.IsCCW())
+
+	fixed := cw.EnsureCCW()
+	assert.True(t, fixed.IsCCW())
+	assert.Equal(t, ccw, fixed)
+	assert.InEpsilon(t, cw.Area().F64(), fixed.Area().F64(), 1e-12)
+
+	// The original is left alone.
+	assert.False(t, cw.IsCCW())
+}
+
+func TestDistanceToPolygon(t *testing.T) {
+	var (
+		square     = box(0, 0, 1, 1)
+		halfDegree = geo.Degrees(0.5).Radians().F64() * 6371000
+	)
+
+	assert.Equal(t, geo.Meters(0), geo.DistanceToPolygon(geo.LLA{Latitude: 0.5, Longitude: 0.5}, square))
+
+	distance := geo.DistanceToPolygon(geo.LLA{Latitude: 0.5, Longitude: 1.5}, square)
+	assert.InEpsilon(t, halfDegree, distance.F64(), 1e-3)
+}
+
+func TestPolygonCentroid(t *testing.T) {
+	square := box(-0.01, -0.01, 0.01, 0.01)
+	centroid := square.Centroid()
+	assert.InDelta(t, 0, centroid.Latitude.F64(), 1e-6)
+	assert.InDelta(t, 0, centroid.Longitude.F64(), 1e-6)
+
+	// An L-shape, with the notch cut out of the top-right. The average of
+	// the vertices lands right on the inside corner of the notch.
+	l := geo.Polygon{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 0.02},
+		{Latitude: 0.01, Longitude: 0.02},
+		{Latitude: 0.01, Longitude: 0.01},
+		{Latitude: 0.02, Longitude: 0.01},
+		{Latitude: 0.02, Longitude: 0},
+	}
+	centroid = l.Centroid()
+	assert.True(t, l.Contains(centroid))
+	assert.False(t, box(0.01, 0.01, 0.02, 0.02).Contains(centroid))
+	assert.InDelta(t, 0.02/2.4, centroid.Latitude.F64(), 1e-5)
+	assert.InDelta(t, 0.02/2.4, centroid.Longitude.F64(), 1e-5)
+}
+
+func TestPolygonIsSimple(t *testing.T) {
+	assert.True(t, box(0, 0, 1, 1).IsSimple())
+	assert.True(t, append(box(0, 0, 1, 1), geo.LLA{}).IsSimple())
+	assert.True(t, box(10, 179, 11, -179).IsSimple())
+	assert.True(t, geo.Polygon{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 0.02},
+		{Latitude: 0.01, Longitude: 0.02},
+		{Latitude: 0.01, Longitude: 0.01},
+		{Latitude: 0.02, Longitude: 0.01},
+		{Latitude: 0.02, Longitude: 0},
+	}.IsSimple())
+
+	bowTie := geo.Polygon{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 1, Longitude: 0},
+		{Latitude: 0, Longitude: 1},
+	}
+	assert.False(t, bowTie.IsSimple())
+
+	// Touching itself at a vertex.
+	assert.False(t, geo.Polygon{
+		{Latitude: 0, Longitude: 0},
+		{Latitude: 0, Longitude: 2},
+		{Latitude: 1, Longitude: 1},
+		{Latitude: 2, Longitude: 2},
+		{Latitude: 2, Longitude: 0},
+		{Latitude: 1, Longitude: 1},
+	}.IsSimple())
+
+	assert.False(t, geo.Polygon{{}, {Latitude: 1}}.IsSimple())
+	assert.False(t, geo.Polygon{{}, {}, {Latitude: 1}}.IsSimple())
+}
@@ -0,0 +1,147 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// Projector is a map projection, which flattens LLA positions onto a plane,
+// with x increasing to the East (Easting), and y increasing to the North
+// (Northing), both in Meters.
+type Projector interface {
+	// Forward will project the LLA onto the plane.
+	Forward(LLA) (x, y Meters)
+
+	// Inverse will return the LLA of the point on the plane.
+	Inverse(x, y Meters) LLA
+}
+
+// NewSinusoidal will return a Projector for the sinusoidal projection
+// centered on the provided central meridian. See LLAToSinusoidal for more
+// about the projection itself.
+func NewSinusoidal(centralMeridian Degrees) Projector {
+	return sinusoidal{centralMeridian: centralMeridian}
+}
+
+type sinusoidal struct {
+	centralMeridian Degrees
+}
+
+func (s sinusoidal) Forward(lla LLA) (Meters, Meters) {
+	return LLAToSinusoidal(lla, s.centralMeridian)
+}
+
+func (s sinusoidal) Inverse(x, y Meters) LLA {
+	return SinusoidalToLLA(x, y, s.centralMeridian)
+}
+
+// NewAzimuthalEquidistant will return a Projector for the azimuthal
+// equidistant projection centered on the provided LLA -- the classic "radar
+// scope" view, where every point is plotted at its great circle distance from
+// the center, in the direction of its bearing from the center. Distances (and
+// bearings) from the center are correct, but nothing else is.
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude (points returned by Inverse have an Altitude of 0). The point on
+// the opposite side of the Earth from the center is smeared out around the
+// edge of the map, and doesn't have a single projection.
+func NewAzimuthalEquidistant(center LLA) Projector {
+	center.Altitude = 0
+	return azimuthalEquidistant{center: center}
+}
+
+type azimuthalEquidistant struct {
+	center LLA
+}
+
+func (a azimuthalEquidistant) Forward(lla LLA) (Meters, Meters) {
+	var (
+		rho   = haversine(a.center, lla)
+		theta = InitialBearing(a.center, lla).Radians().F64()
+	)
+	return rho * Meters(math.Sin(theta)), rho * Meters(math.Cos(theta))
+}
+
+func (a azimuthalEquidistant) Inverse(x, y Meters) LLA {
+	var (
+		rho   = Meters(math.Hypot(x.F64(), y.F64()))
+		theta = Radians(math.Atan2(x.F64(), y.F64())).Degrees()
+	)
+	return DestinationPoint(a.center, theta, rho)
+}
+
+// projectorStep is the distance (in Meters) to step out from a point when
+// working out how a Projector distorts directions near it.
+const projectorStep Meters = 1
+
+// projectorJacobian will return how the grid x and y change with one Meter
+// of East and one Meter of North at the LLA, worked out numerically, as
+// [[dx/dE, dx/dN], [dy/dE, dy/dN]].
+func projectorJacobian(lla LLA, proj Projector) [2][2]float64 {
+	var (
+		x, y   = proj.Forward(lla)
+		ex, ey = proj.Forward(DestinationPoint(lla, 90, projectorStep))
+		nx, ny = proj.Forward(DestinationPoint(lla, 0, projectorStep))
+	)
+	return [2][2]float64{
+		{(ex - x).F64() / projectorStep.F64(), (nx - x).F64() / projectorStep.F64()},
+		{(ey - y).F64() / projectorStep.F64(), (ny - y).F64() / projectorStep.F64()},
+	}
+}
+
+// TrueToGridBearing will turn a true bearing (in Degrees clockwise from true
+// North) at the LLA into a grid bearing (in Degrees clockwise from grid
+// North, the direction of increasing y) on the Projector, within [0, 360).
+//
+// The difference between the two is the grid convergence, which is worked
+// out numerically by projecting points just to the East and North of the
+// LLA, so this will work with any Projector. For projections that aren't
+// conformal the convergence is different for different bearings, which this
+// takes into account.
+func TrueToGridBearing(lla LLA, trueBearing Degrees, proj Projector) Degrees {
+	var (
+		j = projectorJacobian(lla, proj)
+		e = math.Sin(trueBearing.Radians().F64())
+		n = math.Cos(trueBearing.Radians().F64())
+	)
+	return normalizeBearing(Radians(math.Atan2(
+		j[0][0]*e+j[0][1]*n,
+		j[1][0]*e+j[1][1]*n,
+	)).Degrees())
+}
+
+// GridToTrueBearing will invert TrueToGridBearing, turning a grid bearing
+// on the Projector at the LLA back into a true bearing, within [0, 360).
+func GridToTrueBearing(lla LLA, gridBearing Degrees, proj Projector) Degrees {
+	var (
+		j   = projectorJacobian(lla, proj)
+		x   = math.Sin(gridBearing.Radians().F64())
+		y   = math.Cos(gridBearing.Radians().F64())
+		det = j[0][0]*j[1][1] - j[0][1]*j[1][0]
+	)
+	return normalizeBearing(Radians(math.Atan2(
+		(j[1][1]*x-j[0][1]*y)/det,
+		(j[0][0]*y-j[1][0]*x)/det,
+	)).Degrees())
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,80 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinusoidalProjector(t *testing.T) {
+	var (
+		proj = geo.NewSinusoidal(-77)
+		lla  = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+	)
+
+	x, y := proj.Forward(lla)
+	ex, ey := geo.LLAToSinusoidal(lla, -77)
+	assert.Equal(t, ex, x)
+	assert.Equal(t, ey, y)
+
+	back := proj.Inverse(x, y)
+	assert.InDelta(t, lla.Latitude.F64(), back.Latitude.F64(), 1e-9)
+	assert.InDelta(t, lla.Longitude.F64(), back.Longitude.F64(), 1e-9)
+}
+
+func TestAzimuthalEquidistantProjector(t *testing.T) {
+	var (
+		center = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30}
+		proj   = geo.NewAzimuthalEquidistant(center)
+	)
+
+	x, y := proj.Forward(center)
+	assert.InDelta(t, 0, x.F64(), 1e-9)
+	assert.InDelta(t, 0, y.F64(), 1e-9)
+
+	// 500km out at a bearing of 30 is 500km from the middle of the map, 30
+	// Degrees clockwise from y.
+	target := geo.DestinationPoint(center, 30, 500000)
+	x, y = proj.Forward(target)
+	assert.InEpsilon(t, 500000*math.Sin(geo.Degrees(30).Radians().F64()), x.F64(), 1e-9)
+	assert.InEpsilon(t, 500000*math.Cos(geo.Degrees(30).Radians().F64()), y.F64(), 1e-9)
+
+	back := proj.Inverse(x, y)
+	assert.InDelta(t, target.Latitude.F64(), back.Latitude.F64(), 1e-9)
+	assert.InDelta(t, target.Longitude.F64(), back.Longitude.F64(), 1e-9)
+	assert.Equal(t, geo.Meters(0), back.Altitude)
+
+	// Distances from the center hold up even half way around the world.
+	far := geo.LLA{Latitude: -33.8688, Longitude: 151.2093}
+	d, err := geo.HaversineDistance(geo.LLA{Latitude: center.Latitude, Longitude: center.Longitude}, far)
+	assert.NoError(t, err)
+	x, y = proj.Forward(far)
+	assert.InEpsilon(t, d.F64(), math.Hypot(x.F64(), y.F64()), 1e-9)
+}
+
+func TestTrueToGridBearing(t *testing.T) {
+	var (
+		proj = geo.NewSinusoidal(-77)
+		on   = geo.LLA{Latitude: 38.897957, Longitude: -77}
+		off  = geo.LLA{Latitude: 38.897957, Longitude: -70}
+	)
+
+	// Along the central meridian, the grid lines up with true North.
+	for _, bearing := range []geo.Degrees{0, 45, 90, 180, 300} {
+		assert.InDelta(t, bearing.F64(), geo.TrueToGridBearing(on, bearing, proj).F64(), 1e-4)
+		assert.InDelta(t, bearing.F64(), geo.GridToTrueBearing(on, bearing, proj).F64(), 1e-4)
+	}
+
+	// Off to the East, meridians lean in towards the central meridian as
+	// they go North, so true North is to the left of grid North.
+	grid := geo.TrueToGridBearing(off, 0, proj)
+	assert.True(t, grid > 270)
+
+	for _, bearing := range []geo.Degrees{0, 45, 90, 180, 300} {
+		grid := geo.TrueToGridBearing(off, bearing, proj)
+		assert.InDelta(t, bearing.F64(), geo.GridToTrueBearing(off, grid, proj).F64(), 1e-6)
+	}
+}
@@ -0,0 +1,86 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// StandardRefractivityK is the usual effective Earth radius factor used for
+// radio line of sight -- the so called "4/3 Earth".
+const StandardRefractivityK float64 = 4.0 / 3.0
+
+// EffectiveEarthRadius will return the radius of the Earth scaled by k, which
+// for radio propagation is usually StandardRefractivityK.
+//
+// Radio waves in the lower atmosphere bend slightly back towards the ground,
+// and rather than bending the ray, it's a lot easier to straighten the ray
+// and make the Earth bigger (that is, flatter) to match. This uses the same
+// spherical Earth radius as HaversineDistance.
+func EffectiveEarthRadius(k float64) Meters {
+	return Meters(k * earthRadiusMeters)
+}
+
+// RadioLineOfSight will return true if there's a clear radio path between the
+// two points over a smooth Earth, using the EffectiveEarthRadius with the
+// StandardRefractivityK. The Altitude of each point is its height above the
+// surface, which means a pair of points can see each other a bit past the
+// geometric horizon.
+//
+// Terrain is not considered at all.
+func RadioLineOfSight(a, b LLA) bool {
+	return lineOfSight(a, b, StandardRefractivityK)
+}
+
+// lineOfSight will check if the straight line between a and b clears the
+// surface of a sphere k times the size of the Earth. The ground distance
+// between the points is kept the same, which means the angle between them
+// on the bigger sphere shrinks by k.
+func lineOfSight(a, b LLA, k float64) bool {
+	var (
+		radius = EffectiveEarthRadius(k).F64()
+		theta  = unitVector(a).angle(unitVector(b)).F64() / k
+
+		ra = radius + a.Altitude.F64()
+		rb = radius + b.Altitude.F64()
+
+		// a is at (ra, 0), and b is at (bx, by), both in the plane of the
+		// great circle between them.
+		bx = rb * math.Cos(theta)
+		by = rb * math.Sin(theta)
+
+		dx = bx - ra
+		dy = by
+	)
+
+	if ra < radius || rb < radius {
+		return false
+	}
+
+	// Find the point on the segment closest to the center of the sphere.
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, -(ra*dx)/length))
+	}
+	return math.Hypot(ra+t*dx, t*dy) >= radius
+}
+
+// vim: foldmethod=marker
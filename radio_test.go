@@ -0,0 +1,41 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveEarthRadius(t *testing.T) {
+	assert.InEpsilon(t, 6371000, geo.EffectiveEarthRadius(1).F64(), 1e-12)
+	assert.InEpsilon(t, 6371000*4.0/3.0, geo.EffectiveEarthRadius(geo.StandardRefractivityK).F64(), 1e-12)
+}
+
+func TestRadioLineOfSight(t *testing.T) {
+	var (
+		r      = 6371000.0
+		height = 100.0
+
+		// The distance to the geometric horizon from each antenna, and
+		// the (longer) distance to the radio horizon.
+		geometric = math.Sqrt(2 * r * height)
+		radio     = math.Sqrt(2 * r * 4 / 3 * height)
+
+		a       = geo.LLA{Latitude: 0, Longitude: 0, Altitude: geo.Meters(height)}
+		between = func(distance float64) geo.LLA {
+			return geo.DestinationPoint(a, 90, geo.Meters(distance))
+		}
+	)
+
+	// Just past the geometric horizon, but well inside the radio one.
+	assert.True(t, geo.RadioLineOfSight(a, between(2*geometric+1000)))
+	assert.True(t, geo.RadioLineOfSight(a, between(2*radio-1000)))
+	assert.False(t, geo.RadioLineOfSight(a, between(2*radio+1000)))
+
+	// Close by points can always see each other.
+	assert.True(t, geo.RadioLineOfSight(a, between(10)))
+	assert.True(t, geo.RadioLineOfSight(a, a))
+}
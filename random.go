@@ -0,0 +1,62 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+	"math/rand"
+)
+
+// RandomLLA will return a random point on the surface of the Earth, with
+// every part of the surface equally likely, and an Altitude of 0.
+//
+// Picking the Latitude uniformly would bunch the points up at the poles
+// (since there's a lot less surface up there), so the sine of the Latitude
+// is picked uniformly instead, which is what gets the points spread evenly.
+func RandomLLA(r *rand.Rand) LLA {
+	return RandomLLAInBox(r, BoundingBox{South: -90, West: -180, North: 90, East: 180})
+}
+
+// RandomLLAInBox will return a random point within the BoundingBox, with
+// every part of the surface within it equally likely, and an Altitude of 0.
+// BoundingBoxes that cross the anti-meridian are handled.
+func RandomLLAInBox(r *rand.Rand, box BoundingBox) LLA {
+	var (
+		sinSouth = math.Sin(box.South.Radians().F64())
+		sinNorth = math.Sin(box.North.Radians().F64())
+		width    = box.East - box.West
+	)
+
+	if width < 0 {
+		width += 360
+	}
+
+	var (
+		lat = Radians(math.Asin(sinSouth + r.Float64()*(sinNorth-sinSouth))).Degrees()
+		lon = box.West + Degrees(r.Float64())*width
+	)
+	if lon >= 180 {
+		lon -= 360
+	}
+	return LLA{Latitude: lat, Longitude: lon}
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,58 @@
+package geo_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomLLA(t *testing.T) {
+	var (
+		r       = rand.New(rand.NewSource(42))
+		samples = 100000
+		bands   = make([]int, 6)
+	)
+
+	for i := 0; i < samples; i++ {
+		lla := geo.RandomLLA(r)
+		assert.True(t, lla.Latitude >= -90 && lla.Latitude <= 90)
+		assert.True(t, lla.Longitude >= -180 && lla.Longitude < 180)
+		assert.Equal(t, geo.Meters(0), lla.Altitude)
+		bands[int((lla.Latitude+90)/30)%6]++
+	}
+
+	// The fraction of the sphere between two Latitudes is half of the
+	// difference of their sines.
+	for i, count := range bands {
+		var (
+			south    = geo.Degrees(-90 + 30*i).Radians().F64()
+			north    = geo.Degrees(-60 + 30*i).Radians().F64()
+			expected = (math.Sin(north) - math.Sin(south)) / 2
+		)
+		assert.InDelta(t, expected, float64(count)/float64(samples), 0.005)
+	}
+}
+
+func TestRandomLLAInBox(t *testing.T) {
+	var (
+		r       = rand.New(rand.NewSource(42))
+		box     = geo.BoundingBox{South: 10, West: 170, North: 20, East: -170}
+		wrapped = 0
+	)
+
+	for i := 0; i < 10000; i++ {
+		lla := geo.RandomLLAInBox(r, box)
+		assert.True(t, lla.Latitude >= 10 && lla.Latitude <= 20)
+		assert.True(t, lla.Longitude >= 170 || lla.Longitude <= -170)
+		if lla.Longitude < 0 {
+			wrapped++
+		}
+	}
+
+	// The box is split evenly either side of the anti-meridian.
+	assert.InDelta(t, 0.5, float64(wrapped)/10000, 0.02)
+}
@@ -0,0 +1,184 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// rayToEllipsoid will return how far along the direction d a ray starting at
+// o has to travel to hit the surface of an ellipsoid with the semimajor axis
+// a and semiminor axis b, or false if the ray never hits it. The distance is
+// in units of the length of d.
+func rayToEllipsoid(o, d vector, a, b float64) (float64, bool) {
+	var (
+		os = vector{x: o.x / a, y: o.y / a, z: o.z / b}
+		ds = vector{x: d.x / a, y: d.y / a, z: d.z / b}
+
+		qa = ds.dot(ds)
+		qb = 2 * os.dot(ds)
+		qc = os.dot(os) - 1
+
+		disc = qb*qb - 4*qa*qc
+	)
+
+	if qa == 0 || disc < 0 {
+		return 0, false
+	}
+
+	var (
+		sqrt = math.Sqrt(disc)
+		near = (-qb - sqrt) / (2 * qa)
+		far  = (-qb + sqrt) / (2 * qa)
+	)
+
+	switch {
+	case near >= 0:
+		return near, true
+	case far >= 0:
+		// Starting from inside of the ellipsoid, so the ray will hit the
+		// surface on the way out.
+		return far, true
+	default:
+		return 0, false
+	}
+}
+
+// RayToSurface will cast a ray from the observer at the provided LLA along
+// the direction of the AER (the Range is ignored), and return the point where
+// the ray hits the surface of the CoordinateSystem. If the ray points away
+// from the surface, or misses the Earth entirely, an error is returned.
+//
+// The surface is taken to be an ellipsoid of revolution (which a sphere is,
+// too), with the semimajor axis out to the Equator and the semiminor axis out
+// to the North Pole.
+func RayToSurface(cs CoordinateSystem, origin LLA, look AER) (LLA, error) {
+	var (
+		o = cs.LLAToXYZ(origin).vector()
+		d = cs.ENUToXYZ(origin, look.UnitVector()).vector().sub(o)
+
+		a = cs.LLAToXYZ(LLA{}).vector().norm()
+		b = cs.LLAToXYZ(LLA{Latitude: 90}).Z.F64()
+	)
+
+	t, ok := rayToEllipsoid(o, d, a, b)
+	if !ok {
+		return LLA{}, fmt.Errorf("geo.RayToSurface: ray does not hit the surface")
+	}
+	return SubPoint(cs, o.add(d.scale(t)).xyz()), nil
+}
+
+// rayIterations is the number of iterations RayToAltitude will spend
+// narrowing in on each of the points it's looking for along the ray.
+const rayIterations = 100
+
+// raySurfaceSlack is how far (in Meters) below the surface RayToAltitude will
+// let a ray dip before deciding it hit the surface, so that a level ray from
+// the surface isn't thrown out due to floating point noise.
+const raySurfaceSlack Meters = 1e-3
+
+// RayToAltitude will cast a ray from the observer at the provided LLA along
+// the direction of the AER (the Range is ignored), and return the first point
+// along the ray with the target Altitude, such as where a look direction
+// crosses a flight level.
+//
+// A straight ray drops towards the surface for a while (unless it's pointed
+// up), and then climbs away from the Earth, so a target Altitude below the
+// observer can only be reached on the way down, and a target Altitude above
+// the observer on the way up. If the ray levels off above a lower target
+// Altitude, or hits the surface on the way to a higher one, an error is
+// returned. If the observer is already at the target Altitude, the observer's
+// LLA is returned as-is.
+//
+// This works with any CoordinateSystem, by searching along the ray for the
+// target Altitude, rather than solving for it directly.
+func RayToAltitude(cs CoordinateSystem, origin LLA, look AER, targetAlt Meters) (LLA, error) {
+	if origin.Altitude == targetAlt {
+		return origin, nil
+	}
+
+	var (
+		o = cs.LLAToXYZ(origin).vector()
+		d = cs.ENUToXYZ(origin, look.UnitVector()).vector().sub(o)
+
+		at = func(t float64) LLA {
+			return cs.XYZToLLA(o.add(d.scale(t)).xyz())
+		}
+		altitude = func(t float64) Meters {
+			return at(t).Altitude
+		}
+
+		// Anything further than the diameter of the Earth is on the way out.
+		lo, hi = 0.0, 2 * o.norm()
+	)
+
+	// Find the lowest point along the ray with a golden section search.
+	phi := (math.Sqrt(5) - 1) / 2
+	for i := 0; i < rayIterations; i++ {
+		var (
+			a = hi - phi*(hi-lo)
+			b = lo + phi*(hi-lo)
+		)
+		if altitude(a) < altitude(b) {
+			hi = b
+		} else {
+			lo = a
+		}
+	}
+	lowest := (lo + hi) / 2
+
+	// bisect will find where the Altitude crosses the target between lo and
+	// hi, where the Altitude at lo is on the same side of the target as the
+	// Altitude at from.
+	bisect := func(lo, hi float64, from Meters) LLA {
+		below := from < targetAlt
+		for i := 0; i < rayIterations; i++ {
+			mid := (lo + hi) / 2
+			if (altitude(mid) < targetAlt) == below {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		return at((lo + hi) / 2)
+	}
+
+	if targetAlt < origin.Altitude {
+		if altitude(lowest) > targetAlt {
+			return LLA{}, fmt.Errorf("geo.RayToAltitude: ray does not drop to the target Altitude")
+		}
+		return bisect(0, lowest, origin.Altitude), nil
+	}
+
+	if floor := math.Min(0, origin.Altitude.F64()); altitude(lowest) < Meters(floor)-raySurfaceSlack {
+		return LLA{}, fmt.Errorf("geo.RayToAltitude: ray hits the surface first")
+	}
+
+	// Step out along the ray until it's above the target.
+	far := math.Max(lowest, 1)
+	for altitude(far) < targetAlt {
+		far *= 2
+	}
+	return bisect(lowest, far, altitude(lowest)), nil
+}
+
+// vim: foldmethod=marker
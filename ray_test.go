@@ -0,0 +1,66 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRayToAltitudeLevel(t *testing.T) {
+	var (
+		r      = 6371000.0
+		sphere = geo.Sphere(geo.Meters(r))
+		origin = geo.LLA{Latitude: 10, Longitude: 20}
+	)
+
+	// Looking out level, the ray climbs away from the curve of the Earth, and
+	// crosses 10km at the tangent distance.
+	lla, err := geo.RayToAltitude(sphere, origin, geo.AER{Azimuth: 45}, 10000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 10000, lla.Altitude.F64(), 1e-3)
+	assert.InEpsilon(t,
+		math.Sqrt((r+10000)*(r+10000)-r*r),
+		distance(sphere.LLAToXYZ(origin), sphere.LLAToXYZ(lla)),
+		1e-6,
+	)
+
+	// At the same Altitude, that's where the observer is.
+	lla, err = geo.RayToAltitude(sphere, origin, geo.AER{Azimuth: 45}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, origin, lla)
+
+	// But the ray never drops below the observer.
+	_, err = geo.RayToAltitude(sphere, geo.LLA{Altitude: 1000}, geo.AER{Azimuth: 45}, 500)
+	assert.Error(t, err)
+}
+
+func TestRayToAltitudeDown(t *testing.T) {
+	var (
+		wgs    = geo.WGS84()
+		origin = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 10000}
+		look   = geo.AER{Azimuth: 30, Elevation: -10}
+	)
+
+	lla, err := geo.RayToAltitude(wgs, origin, look, 5000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5000, lla.Altitude.F64(), 1e-3)
+
+	// And it's along the look direction.
+	enu := wgs.LLAToENU(origin, lla)
+	assert.InDelta(t, 30, geo.Radians(math.Atan2(enu.East.F64(), enu.North.F64())).Degrees().F64(), 1e-6)
+	assert.InDelta(t, -10, geo.Radians(math.Atan2(
+		enu.Up.F64(), math.Hypot(enu.East.F64(), enu.North.F64()),
+	)).Degrees().F64(), 1e-6)
+
+	// Pointing down at something above runs into the ground first.
+	_, err = geo.RayToAltitude(wgs, origin, look, 20000)
+	assert.Error(t, err)
+
+	// Pointing up gets there just fine.
+	lla, err = geo.RayToAltitude(wgs, origin, geo.AER{Azimuth: 30, Elevation: 10}, 20000)
+	assert.NoError(t, err)
+	assert.InDelta(t, 20000, lla.Altitude.F64(), 1e-3)
+}
@@ -0,0 +1,71 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// RefractionModel describes how the atmosphere bends the line of sight. Since
+// the atmosphere gets denser closer to the surface, things look higher above
+// the horizon than they actually are, and a RefractionModel is able to undo
+// that.
+type RefractionModel interface {
+
+	// GeometricElevation will take the apparent Elevation (as measured, say,
+	// by a RADAR), and return the geometric Elevation the target actually
+	// is at.
+	GeometricElevation(apparent Degrees) Degrees
+}
+
+// StandardRefraction will return a RefractionModel for a standard atmosphere
+// (10°C, 101.0 kPa) at the surface, using Bennett's formula for the refraction
+// angle.
+//
+// This is good to a small fraction of a Degree all the way down to the
+// horizon. Apparent elevations under -1 Degree are corrected as if they were
+// at -1 Degree, since the formula falls apart below that.
+func StandardRefraction() RefractionModel {
+	return bennett{}
+}
+
+type bennett struct{}
+
+func (b bennett) GeometricElevation(apparent Degrees) Degrees {
+	h := math.Max(apparent.F64(), -1)
+
+	// Bennett's formula, in arc minutes.
+	minutes := 1 / math.Tan(Degrees(h+7.31/(h+4.4)).Radians().F64())
+	return apparent - Degrees(math.Max(minutes, 0)/60)
+}
+
+// ENUWithRefraction will translate the AER into an ENU just like AER.ENU,
+// except the Elevation is first corrected from the apparent Elevation to the
+// geometric Elevation using the provided RefractionModel.
+func (aed AER) ENUWithRefraction(model RefractionModel) ENU {
+	return AER{
+		Azimuth:   aed.Azimuth,
+		Elevation: model.GeometricElevation(aed.Elevation),
+		Range:     aed.Range,
+	}.ENU()
+}
+
+// vim: foldmethod=marker
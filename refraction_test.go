@@ -0,0 +1,36 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardRefraction(t *testing.T) {
+	model := geo.StandardRefraction()
+
+	// At the horizon, the refraction is a little over half a Degree.
+	horizon := model.GeometricElevation(0)
+	assert.InDelta(t, -0.5746, horizon.F64(), 1e-3)
+
+	low := model.GeometricElevation(1)
+	assert.Less(t, low.F64(), 1.0)
+	assert.InDelta(t, 1-0.41, low.F64(), 1e-2)
+
+	// And basically nothing straight up.
+	assert.InDelta(t, 90, model.GeometricElevation(90).F64(), 1e-6)
+	assert.InDelta(t, 45, model.GeometricElevation(45).F64(), 0.02)
+}
+
+func TestAERENUWithRefraction(t *testing.T) {
+	aer := geo.AER{Azimuth: 30, Elevation: 2, Range: 100000}
+
+	apparent := aer.ENU()
+	geometric := aer.ENUWithRefraction(geo.StandardRefraction())
+
+	assert.Less(t, geometric.Up.F64(), apparent.Up.F64())
+	assert.InEpsilon(t, apparent.Heading().F64(), geometric.Heading().F64(), 1e-9)
+	assert.InEpsilon(t, apparent.Distance().F64(), geometric.Distance().F64(), 1e-9)
+}
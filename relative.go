@@ -0,0 +1,63 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"time"
+)
+
+// RelativeLLA will return where the target is, and how fast it appears to be
+// moving (in Meters per second), as seen from a moving reference, both in the
+// WGS84 ENU tangent plane at the reference's current location.
+//
+// The reference's velocity is estimated from how far it moved between the
+// previous and current locations over dt. Only a single fix of the target is
+// given, so the target is treated as stationary, and the apparent velocity
+// is entirely due to the reference moving (that is, the reference's velocity,
+// reversed). The rotation of the tangent plane as the reference moves is not
+// taken into account, which is fine for the small distances a reference moves
+// between fixes.
+//
+// If dt isn't positive, there's no way to estimate a velocity, so the
+// returned velocity is zero.
+func RelativeLLA(refCurrent, refPrevious, target LLA, dt time.Duration) (ENU, ENU) {
+	var (
+		wgs      = WGS84()
+		position = wgs.LLAToENU(refCurrent, target)
+	)
+
+	if dt <= 0 {
+		return position, ENU{}
+	}
+
+	var (
+		previous = wgs.LLAToENU(refCurrent, refPrevious)
+		seconds  = Meters(dt.Seconds())
+	)
+
+	return position, ENU{
+		East:  previous.East / seconds,
+		North: previous.North / seconds,
+		Up:    previous.Up / seconds,
+	}
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,43 @@
+package geo_test
+
+import (
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeLLAStationary(t *testing.T) {
+	var (
+		ref    = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 100}
+		target = geo.OffsetLLA(geo.WGS84(), ref, geo.ENU{East: 300, North: 400, Up: 50})
+	)
+
+	position, velocity := geo.RelativeLLA(ref, ref, target, time.Second)
+	assert.InDelta(t, 300, position.East.F64(), 1e-6)
+	assert.InDelta(t, 400, position.North.F64(), 1e-6)
+	assert.InDelta(t, 50, position.Up.F64(), 1e-6)
+	assert.InDelta(t, 0, velocity.Distance().F64(), 1e-9)
+}
+
+func TestRelativeLLAMoving(t *testing.T) {
+	var (
+		wgs      = geo.WGS84()
+		previous = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 1000}
+
+		// Flying North at 100 Meters per second, and climbing at 5.
+		current = geo.OffsetLLA(wgs, previous, geo.ENU{North: 1000, Up: 50})
+		target  = geo.OffsetLLA(wgs, current, geo.ENU{East: 2000})
+	)
+
+	position, velocity := geo.RelativeLLA(current, previous, target, 10*time.Second)
+	assert.InDelta(t, 2000, position.East.F64(), 1e-6)
+	assert.InDelta(t, 0, velocity.East.F64(), 1e-3)
+	assert.InDelta(t, -100, velocity.North.F64(), 0.01)
+	assert.InDelta(t, -5, velocity.Up.F64(), 0.05)
+
+	_, velocity = geo.RelativeLLA(current, previous, target, 0)
+	assert.Equal(t, geo.ENU{}, velocity)
+}
@@ -0,0 +1,142 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// mercatorY will return the Mercator-stretched Latitude (the isometric
+// latitude) of the angle, which is what rhumb lines are straight lines in.
+func mercatorY(lat Degrees) float64 {
+	return math.Log(math.Tan(math.Pi/4 + lat.Radians().F64()/2))
+}
+
+// RhumbBearing will return the constant bearing (in Degrees clockwise from
+// North, within [0, 360)) of the rhumb line (loxodrome) from a to b. This
+// is the course one would hold on a compass to get from a to b, which is
+// longer than the great circle route, but a lot easier to steer.
+//
+// The rhumb line always goes the short way around, crossing the anti-meridian
+// if need be. Altitude is ignored.
+func RhumbBearing(a, b LLA) Degrees {
+	var (
+		dPsi = mercatorY(b.Latitude) - mercatorY(a.Latitude)
+		dLon = normalizeLongitude(b.Longitude - a.Longitude).Radians().F64()
+	)
+	return normalizeBearing(Radians(math.Atan2(dLon, dPsi)).Degrees())
+}
+
+// RhumbDistance will return the distance along the rhumb line (loxodrome)
+// from a to b, which is never shorter than the great circle distance, and can
+// be a good deal longer for long East / West routes at high Latitudes.
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude.
+func RhumbDistance(a, b LLA) Meters {
+	var (
+		lat1 = a.Latitude.Radians().F64()
+		lat2 = b.Latitude.Radians().F64()
+		dLat = lat2 - lat1
+		dPsi = mercatorY(b.Latitude) - mercatorY(a.Latitude)
+		dLon = normalizeLongitude(b.Longitude - a.Longitude).Radians().F64()
+
+		// q is how much the East / West leg is squashed by the meridians
+		// getting closer together. Along a parallel, dLat / dPsi is 0 / 0,
+		// so just use the cosine of the Latitude.
+		q = math.Cos(lat1)
+	)
+
+	if math.Abs(dPsi) > 1e-12 {
+		q = dLat / dPsi
+	}
+	return Meters(earthRadiusMeters * math.Sqrt(dLat*dLat+q*q*dLon*dLon))
+}
+
+// RouteComparison will return both the great circle distance and initial
+// bearing, and the rhumb line distance and (constant) bearing, from a to b,
+// which is handy when showing off the tradeoff between the two.
+//
+// Just like HaversineDistance, this will return an error if either of the
+// provided geo.LLA structs have an Altitude other than 0.
+func RouteComparison(a, b LLA) (Meters, Meters, Degrees, Degrees, error) {
+	gcDist, gcBearing, err := Inverse(a, b)
+	if err != nil {
+		return 0, 0, 0, 0, fmt.Errorf("geo.RouteComparison: %w", err)
+	}
+	return gcDist, RhumbDistance(a, b), gcBearing, RhumbBearing(a, b), nil
+}
+
+// GreatCircleAsRhumbLegs will return the waypoints of a route from a to b
+// that follows the great circle between them as the provided number of
+// rhumb line legs, which is how a ship (which would much rather hold a
+// constant bearing) approximates a great circle. The waypoints are evenly
+// spaced along the great circle, starting with a and ending with b, and the
+// navigator changes course to the RhumbBearing of the next leg at each one.
+//
+// The more legs, the closer the route is to the length of the great circle.
+// See GreatCirclePath for how the waypoints are placed. A route needs at
+// least one leg; asking for fewer will return nil.
+func GreatCircleAsRhumbLegs(a, b LLA, legs int) []LLA {
+	if legs < 1 {
+		return nil
+	}
+	return GreatCirclePath(a, b, legs+1)
+}
+
+// RhumbMidpoint will return the point halfway along the rhumb line between a
+// and b.
+//
+// Since the distance along a rhumb line is proportional to the change in
+// Latitude, the midpoint Latitude is just the mean of the two. The Longitude
+// is where things get interesting, since rhumb lines are straight in Mercator
+// space, so the Longitude is found along the Mercator-stretched Latitude. The
+// Altitude of the returned point is the mean of the two Altitudes.
+func RhumbMidpoint(a, b LLA) LLA {
+	var (
+		lat1 = a.Latitude
+		lat2 = b.Latitude
+		lat3 = (lat1 + lat2) / 2
+
+		lon1 = a.Longitude.Radians().F64()
+		lon2 = lon1 + normalizeLongitude(b.Longitude-a.Longitude).Radians().F64()
+		lon3 = (lon1 + lon2) / 2
+
+		psi1 = mercatorY(lat1)
+		psi2 = mercatorY(lat2)
+		psi3 = mercatorY(lat3)
+	)
+
+	// Along a parallel of Latitude the rhumb line is a constant Latitude, and
+	// the Longitude is just the mean.
+	if math.Abs(psi2-psi1) > 1e-12 {
+		lon3 = ((lon2-lon1)*psi3 + lon1*psi2 - lon2*psi1) / (psi2 - psi1)
+	}
+
+	return LLA{
+		Latitude:  lat3,
+		Longitude: normalizeLongitude(Radians(lon3).Degrees()),
+		Altitude:  (a.Altitude + b.Altitude) / 2,
+	}
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,142 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRhumbBearing(t *testing.T) {
+	assert.InDelta(t, 90, geo.RhumbBearing(geo.LLA{Latitude: 50, Longitude: -60}, geo.LLA{Latitude: 50, Longitude: 60}).F64(), 1e-9)
+	assert.InDelta(t, 0, geo.RhumbBearing(geo.LLA{Latitude: 10}, geo.LLA{Latitude: 20}).F64(), 1e-9)
+	assert.InDelta(t, 270, geo.RhumbBearing(geo.LLA{Longitude: -179}, geo.LLA{Longitude: 179}).F64(), 1e-9)
+
+	// Dover to Calais, which is about 116.64 Degrees.
+	dover := geo.LLA{Latitude: 51.127, Longitude: 1.338}
+	calais := geo.LLA{Latitude: 50.964, Longitude: 1.853}
+	assert.InDelta(t, 116.7, geo.RhumbBearing(dover, calais).F64(), 0.1)
+}
+
+func TestRhumbMidpoint(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 10, Longitude: -70}
+		b = geo.LLA{Latitude: 50, Longitude: 10}
+	)
+
+	mid := geo.RhumbMidpoint(a, b)
+	assert.InEpsilon(t, 30, mid.Latitude.F64(), 1e-12)
+
+	// The midpoint is on the rhumb line, so the bearing is the same from
+	// either end of the line.
+	bearing := geo.RhumbBearing(a, b)
+	assert.InEpsilon(t, bearing.F64(), geo.RhumbBearing(a, mid).F64(), 1e-9)
+	assert.InEpsilon(t, bearing.F64(), geo.RhumbBearing(mid, b).F64(), 1e-9)
+}
+
+func TestRhumbMidpointParallel(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 50, Longitude: -60}
+		b = geo.LLA{Latitude: 50, Longitude: 60}
+	)
+
+	mid := geo.RhumbMidpoint(a, b)
+	assert.InEpsilon(t, 50, mid.Latitude.F64(), 1e-12)
+	assert.InDelta(t, 0, mid.Longitude.F64(), 1e-12)
+
+	// The great circle midpoint bows well North of the rhumb line.
+	gcLatitude := geo.Radians(math.Atan(math.Tan(geo.Degrees(50).Radians().F64()) / math.Cos(geo.Degrees(60).Radians().F64()))).Degrees()
+	assert.Greater(t, gcLatitude.F64()-mid.Latitude.F64(), 15.0)
+
+	// And across the anti-meridian.
+	mid = geo.RhumbMidpoint(geo.LLA{Latitude: 20, Longitude: 170}, geo.LLA{Latitude: 20, Longitude: -150})
+	assert.InEpsilon(t, 20, mid.Latitude.F64(), 1e-12)
+	assert.InEpsilon(t, -170, mid.Longitude.F64(), 1e-12)
+}
+
+func TestRhumbDistance(t *testing.T) {
+	// Along a meridian, or the Equator, a rhumb line is a great circle.
+	for _, pair := range [][2]geo.LLA{
+		{{Latitude: 10, Longitude: 20}, {Latitude: 50, Longitude: 20}},
+		{{Latitude: 0, Longitude: 20}, {Latitude: 0, Longitude: 50}},
+	} {
+		expected, err := geo.HaversineDistance(pair[0], pair[1])
+		assert.NoError(t, err)
+		assert.InEpsilon(t, expected.F64(), geo.RhumbDistance(pair[0], pair[1]).F64(), 1e-9)
+	}
+
+	// Along a parallel, it's the length of the arc of the parallel.
+	assert.InEpsilon(t,
+		6371000*math.Cos(geo.Degrees(60).Radians().F64())*geo.Degrees(30).Radians().F64(),
+		geo.RhumbDistance(
+			geo.LLA{Latitude: 60, Longitude: 170},
+			geo.LLA{Latitude: 60, Longitude: -160},
+		).F64(),
+		1e-9,
+	)
+}
+
+func TestRouteComparison(t *testing.T) {
+	var (
+		london = geo.LLA{Latitude: 51.510357, Longitude: -0.116773}
+		dc     = geo.LLA{Latitude: 38.889931, Longitude: -77.009003}
+	)
+
+	gcDist, rhumbDist, gcBearing, rhumbBearing, err := geo.RouteComparison(dc, london)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 5897658.288856054, gcDist.F64(), 1e-6)
+	assert.True(t, rhumbDist > gcDist)
+	assert.InDelta(t, 6200000, rhumbDist.F64(), 100000)
+
+	// The great circle heads off well North of the rhumb line.
+	assert.True(t, gcBearing < rhumbBearing)
+	assert.InEpsilon(t, geo.RhumbBearing(dc, london).F64(), rhumbBearing.F64(), 1e-12)
+
+	_, _, _, _, err = geo.RouteComparison(geo.LLA{Altitude: 1}, london)
+	assert.EqualError(t, err, "geo.RouteComparison: geo.Inverse: Altitude must be 0")
+}
+
+func TestGreatCircleAsRhumbLegs(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 40.64, Longitude: -73.78}
+		b = geo.LLA{Latitude: 51.47, Longitude: -0.46}
+
+		waypoints = geo.GreatCircleAsRhumbLegs(a, b, 10)
+	)
+
+	assert.Len(t, waypoints, 11)
+	assert.Equal(t, a, waypoints[0])
+	assert.InDelta(t, b.Latitude.F64(), waypoints[10].Latitude.F64(), 1e-9)
+	assert.InDelta(t, b.Longitude.F64(), waypoints[10].Longitude.F64(), 1e-9)
+
+	var total geo.Meters
+	for i, waypoint := range waypoints {
+		// Every waypoint is on the great circle.
+		assert.True(t, geo.Collinear(a, b, waypoint, 1e-3))
+
+		if i == 0 {
+			continue
+		}
+
+		// And every leg is a rhumb line, so the midpoint of the leg is
+		// along the same bearing as the leg itself.
+		var (
+			prev    = waypoints[i-1]
+			bearing = geo.RhumbBearing(prev, waypoint)
+		)
+		assert.InDelta(t, bearing.F64(), geo.RhumbBearing(prev, geo.RhumbMidpoint(prev, waypoint)).F64(), 1e-6)
+		total += geo.RhumbDistance(prev, waypoint)
+	}
+
+	// Holding each bearing for a leg at a time is a little longer than the
+	// great circle, but a lot shorter than a single rhumb line.
+	gc, err := geo.HaversineDistance(a, b)
+	assert.NoError(t, err)
+	assert.Greater(t, total.F64(), gc.F64())
+	assert.InEpsilon(t, gc.F64(), total.F64(), 1e-3)
+	assert.Less(t, total.F64(), geo.RhumbDistance(a, b).F64())
+
+	assert.Nil(t, geo.GreatCircleAsRhumbLegs(a, b, 0))
+}
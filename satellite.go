@@ -0,0 +1,42 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// SatelliteFootprint will return the radius (along the surface of the Earth)
+// of the area that can see a satellite at the provided location -- the
+// spherical cap within which the satellite is at or above the horizon.
+//
+// This only depends on the Altitude of the satellite, and uses the same
+// spherical Earth as HaversineDistance. Anything at or below an Altitude of 0
+// has a footprint of 0.
+func SatelliteFootprint(sat LLA) Meters {
+	if sat.Altitude <= 0 {
+		return 0
+	}
+	angle := math.Acos(earthRadiusMeters / (earthRadiusMeters + sat.Altitude.F64()))
+	return Meters(earthRadiusMeters * angle)
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,23 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatelliteFootprint(t *testing.T) {
+	// At an Altitude of one Earth radius, the horizon is 60° around.
+	footprint := geo.SatelliteFootprint(geo.LLA{Altitude: 6371000})
+	assert.InEpsilon(t, 6371000*math.Pi/3, footprint.F64(), 1e-12)
+
+	leo := geo.SatelliteFootprint(geo.LLA{Latitude: 10, Longitude: 20, Altitude: 408000})
+	meo := geo.SatelliteFootprint(geo.LLA{Latitude: 10, Longitude: 20, Altitude: 20200000})
+	assert.Less(t, leo.F64(), meo.F64())
+	assert.InEpsilon(t, 2221634, leo.F64(), 1e-6)
+
+	assert.Equal(t, geo.Meters(0), geo.SatelliteFootprint(geo.LLA{}))
+}
@@ -0,0 +1,83 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// GroundSampleDistance will return the width of the patch of ground seen by
+// a single pixel of a sensor looking straight down from the given Altitude,
+// where fovPerPixel is the angular field of view of one pixel (the IFOV).
+//
+// This treats the ground under the sensor as flat, which is more than fine
+// for any sensor that isn't looking at a good fraction of the Earth at once.
+func GroundSampleDistance(altitude Meters, fovPerPixel Radians) Meters {
+	return Meters(2 * altitude.F64() * math.Tan(fovPerPixel.F64()/2))
+}
+
+// OffNadirGroundSampleDistance will return the ground sample distance of a
+// sensor looking at the ground along the provided AER (relative to the
+// sensor), where the Range is the slant range to the ground.
+//
+// Looking off-nadir stretches the pixel along the look direction by one over
+// the sine of the grazing angle, which is what is returned here (the pixel
+// is only as wide as the slant range allows across the look direction). As
+// with GroundSampleDistance, the ground is treated as flat. Looking at or
+// above the horizon never hits the ground, so an error is returned.
+func OffNadirGroundSampleDistance(look AER, fovPerPixel Radians) (Meters, error) {
+	if look.Elevation >= 0 {
+		return 0, fmt.Errorf("geo.OffNadirGroundSampleDistance: Elevation must be below the horizon")
+	}
+	if look.Range <= 0 {
+		return 0, fmt.Errorf("geo.OffNadirGroundSampleDistance: Range must be positive")
+	}
+
+	grazing := math.Sin(-look.Elevation.Radians().F64())
+	return GroundSampleDistance(look.Range, fovPerPixel) / Meters(grazing), nil
+}
+
+// CrossRangeResolution will return the cross-range resolution of a
+// synthetic aperture radar, which is the wavelength over twice the aperture
+// angle -- the angle the radar swept through (as seen from the target) while
+// collecting the image. The wider the angle, the finer the resolution.
+//
+// An aperture angle of 0 (or less) has no cross-range resolution at all, and
+// an infinite resolution is returned.
+func CrossRangeResolution(wavelength Meters, apertureAngle Radians) Meters {
+	if apertureAngle <= 0 {
+		return Meters(math.Inf(1))
+	}
+	return wavelength / Meters(2*apertureAngle.F64())
+}
+
+// SyntheticApertureAngle will return the aperture angle swept through
+// between two AER observations of the radar taken from the target (or, just
+// the same, two AER observations of the target taken from a radar that
+// stayed in one place but turned), for use with CrossRangeResolution. This
+// is the angle between the two look directions; the Range of both is
+// ignored.
+func SyntheticApertureAngle(start, end AER) Radians {
+	return start.AngularSeparation(end).Radians()
+}
+
+// vim: foldmethod=marker
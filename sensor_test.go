@@ -0,0 +1,48 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroundSampleDistance(t *testing.T) {
+	// 10 microradians from 500km is about 5m.
+	gsd := geo.GroundSampleDistance(500000, 10e-6)
+	assert.InEpsilon(t, 500000*10e-6, gsd.F64(), 1e-9)
+
+	assert.Equal(t, geo.Meters(0), geo.GroundSampleDistance(500000, 0))
+}
+
+func TestOffNadirGroundSampleDistance(t *testing.T) {
+	nadir, err := geo.OffNadirGroundSampleDistance(geo.AER{Elevation: -90, Range: 500000}, 10e-6)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, geo.GroundSampleDistance(500000, 10e-6).F64(), nadir.F64(), 1e-12)
+
+	// At a 30 Degree grazing angle, the pixel is twice as long.
+	offNadir, err := geo.OffNadirGroundSampleDistance(geo.AER{Azimuth: 45, Elevation: -30, Range: 1000000}, 10e-6)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 2*geo.GroundSampleDistance(1000000, 10e-6).F64(), offNadir.F64(), 1e-9)
+
+	_, err = geo.OffNadirGroundSampleDistance(geo.AER{Elevation: 0, Range: 500000}, 10e-6)
+	assert.Error(t, err)
+	_, err = geo.OffNadirGroundSampleDistance(geo.AER{Elevation: -90}, 10e-6)
+	assert.Error(t, err)
+}
+
+func TestCrossRangeResolution(t *testing.T) {
+	// X-band (3cm) over a 2 Degree aperture is a bit under half a meter.
+	resolution := geo.CrossRangeResolution(0.03, geo.Degrees(2).Radians())
+	assert.InEpsilon(t, 0.03/(2*0.03490658503988659), resolution.F64(), 1e-12)
+
+	assert.True(t, math.IsInf(geo.CrossRangeResolution(0.03, 0).F64(), 1))
+
+	angle := geo.SyntheticApertureAngle(
+		geo.AER{Azimuth: 89, Elevation: 30, Range: 10000},
+		geo.AER{Azimuth: 91, Elevation: 30, Range: 20000},
+	)
+	assert.InDelta(t, geo.Degrees(2*math.Cos(geo.Degrees(30).Radians().F64())).Radians().F64(), angle.F64(), 1e-6)
+}
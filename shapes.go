@@ -0,0 +1,337 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// CirclePolygon will return a Polygon approximating a circle of the provided
+// radius (along the surface of the Earth) around the center, with one vertex
+// every 360/segments Degrees of bearing, starting due North.
+//
+// Every vertex is exactly the radius away from the center, so the edges cut
+// slightly inside the true circle -- more segments will get closer. Fewer
+// than 3 segments can't make a Polygon, so nil is returned.
+func CirclePolygon(center LLA, radius Meters, segments int) Polygon {
+	if segments < 3 {
+		return nil
+	}
+
+	polygon := make(Polygon, segments)
+	for i := range polygon {
+		bearing := Degrees(360 * float64(i) / float64(segments))
+		polygon[i] = DestinationPoint(center, bearing, radius)
+	}
+	return polygon
+}
+
+// BearingSpokes will return count points, each the provided distance from
+// the center, evenly spaced in bearing starting from due North and going
+// clockwise -- the ends of the spokes of a compass rose, or the tick marks on
+// a range ring. Each point is placed using DestinationPoint. If count isn't
+// positive, nil is returned.
+func BearingSpokes(center LLA, distance Meters, count int) []LLA {
+	if count < 1 {
+		return nil
+	}
+
+	spokes := make([]LLA, count)
+	for i := range spokes {
+		bearing := Degrees(360 * float64(i) / float64(count))
+		spokes[i] = DestinationPoint(center, bearing, distance)
+	}
+	return spokes
+}
+
+// ErrorEllipse will return a Polygon approximating an ellipse on the ground
+// around the center, such as the 1-sigma region of a position estimate. The
+// major axis points along the orientation (in Degrees clockwise from North),
+// and the minor axis is at a right angle to it.
+//
+// The first vertex is at the end of the major axis along the orientation,
+// and the vertices go clockwise from there, evenly spaced in angle around
+// the ellipse. Each vertex is placed using DestinationPoint, so the axes are
+// true distances along the surface. Fewer than 3 segments can't make a
+// Polygon, so nil is returned.
+func ErrorEllipse(center LLA, semiMajor, semiMinor Meters, orientation Degrees, segments int) Polygon {
+	if segments < 3 {
+		return nil
+	}
+
+	polygon := make(Polygon, segments)
+	for i := range polygon {
+		var (
+			theta = 2 * math.Pi * float64(i) / float64(segments)
+			major = semiMajor.F64() * math.Cos(theta)
+			minor = semiMinor.F64() * math.Sin(theta)
+
+			bearing = orientation + Radians(math.Atan2(minor, major)).Degrees()
+		)
+		polygon[i] = DestinationPoint(center, bearing, Meters(math.Hypot(major, minor)))
+	}
+	return polygon
+}
+
+// BufferPoints will return the union of the circles of the provided radius
+// around each of the points, such as the coverage area of a set of
+// receivers. There's one Polygon for each set of circles that overlap, so
+// points further apart than twice the radius end up in separate Polygons,
+// and the gap between them isn't covered.
+//
+// The circles are merged on the flat WGS84 tangent plane at the first point
+// of each set, so this is a good answer while each set of overlapping circles
+// is small compared to the Earth. Vertices are placed at the same bearings
+// CirclePolygon uses, plus one wherever two circles cross, so (just like
+// CirclePolygon) the edges cut slightly inside of each circle, with more
+// segments getting closer. A Polygon can't have a hole in it, so a hole in
+// the union (such as the middle of a ring of points) is filled in. Like
+// CirclePolygon, each Polygon winds clockwise.
+//
+// Altitude is ignored, and the returned Polygons have an Altitude of 0. If
+// there are no points, the radius isn't positive, or there are fewer than 3
+// segments, nil is returned.
+func BufferPoints(points []LLA, radius Meters, segments int) []Polygon {
+	if len(points) == 0 || radius <= 0 || segments < 3 {
+		return nil
+	}
+
+	var (
+		wgs      = WGS84()
+		assigned = make([]bool, len(points))
+		polygons = []Polygon{}
+	)
+
+	for i := range points {
+		if assigned[i] {
+			continue
+		}
+		assigned[i] = true
+
+		var (
+			ref     = LLA{Latitude: points[i].Latitude, Longitude: points[i].Longitude}
+			centers = []ENU{{}}
+			enus    = make([]ENU, len(points))
+		)
+
+		for j := i + 1; j < len(points); j++ {
+			if !assigned[j] {
+				enu := wgs.LLAToENU(ref, LLA{Latitude: points[j].Latitude, Longitude: points[j].Longitude})
+				enus[j] = ENU{East: enu.East, North: enu.North}
+			}
+		}
+
+		// Pull in every point that overlaps a circle already in the set,
+		// until there's nothing left that does.
+		for k := 0; k < len(centers); k++ {
+			for j := i + 1; j < len(points); j++ {
+				if assigned[j] || enuDistance(centers[k], enus[j]) >= 2*radius {
+					continue
+				}
+				assigned[j] = true
+				duplicate := false
+				for _, center := range centers {
+					duplicate = duplicate || center == enus[j]
+				}
+				if !duplicate {
+					centers = append(centers, enus[j])
+				}
+			}
+		}
+
+		for _, ring := range circleUnion(centers, radius, segments) {
+			polygon := make(Polygon, len(ring))
+			for k, vertex := range ring {
+				lla := OffsetLLA(wgs, ref, vertex)
+				polygon[k] = LLA{Latitude: lla.Latitude, Longitude: lla.Longitude}
+			}
+			polygons = append(polygons, polygon)
+		}
+	}
+	return polygons
+}
+
+// enuDistance will return the distance between the East / North components
+// of the two points. The Up component is ignored.
+func enuDistance(a, b ENU) Meters {
+	return Meters(math.Hypot((a.East - b.East).F64(), (a.North - b.North).F64()))
+}
+
+// circleArc is a stretch of the edge of one of the circles in circleUnion
+// that isn't inside of any of the other circles, going clockwise from the
+// start bearing to the end bearing (in Radians).
+type circleArc struct {
+	circle     int
+	from, to   int
+	start, end float64
+}
+
+// circleArcKey picks out the arc on the circle that starts where the circle
+// crosses out of the other circle, from.
+type circleArcKey struct {
+	circle, from int
+}
+
+// circleUnion will return the outer rings of the union of circles of the
+// provided radius around each of the (distinct) centers, on a flat plane.
+// The rings go clockwise, with a vertex at each bearing CirclePolygon would
+// use for that many segments, plus one wherever two circles cross.
+//
+// The edge of the union is made of arcs of the circles that aren't inside of
+// any of the other circles. Following an arc clockwise (with the union on the
+// right), it ends where it crosses into another circle, which is exactly
+// where the next arc (on that other circle) starts.
+func circleUnion(centers []ENU, radius Meters, segments int) [][]ENU {
+	var (
+		tau  = 2 * math.Pi
+		wrap = func(r float64) float64 {
+			r = math.Mod(r, tau)
+			if r < 0 {
+				r += tau
+			}
+			return r
+		}
+
+		arcs  = map[circleArcKey]circleArc{}
+		order = []circleArcKey{}
+	)
+
+	for i, center := range centers {
+		type covered struct {
+			by         int
+			start, end float64
+		}
+
+		// Work out the bearings around this circle that are inside of each
+		// of the other circles.
+		intervals := []covered{}
+		for j, other := range centers {
+			d := enuDistance(center, other)
+			if i == j || d >= 2*radius {
+				continue
+			}
+			var (
+				bearing = math.Atan2((other.East - center.East).F64(), (other.North - center.North).F64())
+				halfway = math.Acos((d / (2 * radius)).F64())
+			)
+			intervals = append(intervals, covered{
+				by:    j,
+				start: wrap(bearing - halfway),
+				end:   wrap(bearing + halfway),
+			})
+		}
+
+		if len(intervals) == 0 {
+			key := circleArcKey{circle: i, from: -1}
+			arcs[key] = circleArc{circle: i, from: -1, to: -1, start: 0, end: tau}
+			order = append(order, key)
+			continue
+		}
+
+		// Every uncovered arc starts at the end of one of the intervals, and
+		// runs until the start of the next one.
+		for k, interval := range intervals {
+			inside := false
+			for m, other := range intervals {
+				if m != k && wrap(interval.end-other.start) < wrap(other.end-other.start) {
+					inside = true
+				}
+			}
+			if inside {
+				continue
+			}
+
+			var (
+				next   = k
+				length = wrap(intervals[k].start - interval.end)
+			)
+			for m, other := range intervals {
+				if l := wrap(other.start - interval.end); l < length {
+					next, length = m, l
+				}
+			}
+
+			key := circleArcKey{circle: i, from: interval.by}
+			arcs[key] = circleArc{
+				circle: i,
+				from:   interval.by,
+				to:     intervals[next].by,
+				start:  interval.end,
+				end:    interval.end + length,
+			}
+			order = append(order, key)
+		}
+	}
+
+	var (
+		step    = tau / float64(segments)
+		visited = map[circleArcKey]bool{}
+		rings   = [][]ENU{}
+	)
+
+	for _, first := range order {
+		if visited[first] {
+			continue
+		}
+
+		var (
+			ring = []ENU{}
+			area float64
+		)
+		for key := first; !visited[key]; {
+			visited[key] = true
+			arc := arcs[key]
+
+			bearings := []float64{arc.start}
+			for k := math.Floor(arc.start/step) + 1; k*step < arc.end; k++ {
+				bearings = append(bearings, k*step)
+			}
+			for _, bearing := range bearings {
+				ring = append(ring, ENU{
+					East:  centers[arc.circle].East + Meters(radius.F64()*math.Sin(bearing)),
+					North: centers[arc.circle].North + Meters(radius.F64()*math.Cos(bearing)),
+				})
+			}
+
+			next := circleArcKey{circle: arc.to, from: arc.circle}
+			if _, ok := arcs[next]; !ok {
+				break
+			}
+			key = next
+		}
+
+		// The outer edge goes clockwise, and the edge of a hole goes
+		// counter-clockwise.
+		for k := range ring {
+			var (
+				a = ring[k]
+				b = ring[(k+1)%len(ring)]
+			)
+			area += a.East.F64()*b.North.F64() - b.East.F64()*a.North.F64()
+		}
+		if area < 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings
+}
+
+// vim: foldmethod=marker
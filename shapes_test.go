@@ -0,0 +1,144 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCirclePolygon(t *testing.T) {
+	center := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+
+	circle := geo.CirclePolygon(center, 10000, 36)
+	assert.Len(t, circle, 36)
+	for _, vertex := range circle {
+		meters, err := geo.HaversineDistance(center, vertex)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 10000, meters.F64(), 1e-9)
+	}
+
+	assert.True(t, circle.Contains(center))
+	assert.InEpsilon(t, math.Pi*10000*10000, circle.Area().F64(), 1e-2)
+
+	assert.Nil(t, geo.CirclePolygon(center, 10000, 2))
+}
+
+func TestErrorEllipse(t *testing.T) {
+	center := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+
+	ellipse := geo.ErrorEllipse(center, 200, 50, 30, 8)
+	assert.Len(t, ellipse, 8)
+
+	for i, expected := range []struct {
+		meters  float64
+		bearing float64
+	}{
+		{200, 30},
+		{50, 120},
+		{200, 210},
+		{50, 300},
+	} {
+		vertex := ellipse[i*2]
+
+		meters, err := geo.HaversineDistance(center, vertex)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, expected.meters, meters.F64(), 1e-6)
+		assert.InEpsilon(t, expected.bearing, geo.InitialBearing(center, vertex).F64(), 1e-6)
+	}
+
+	assert.True(t, ellipse.Contains(center))
+	assert.Nil(t, geo.ErrorEllipse(center, 200, 50, 30, 2))
+}
+
+func TestBufferPoints(t *testing.T) {
+	points := []geo.LLA{
+		{Latitude: 38.897957, Longitude: -77.036560},
+		{Latitude: 38.889931, Longitude: -77.009003},
+		{Latitude: 38.881000, Longitude: -77.030000},
+		{Latitude: 38.890000, Longitude: -77.025000},
+	}
+
+	contains := func(buffer []geo.Polygon, point geo.LLA) bool {
+		for _, polygon := range buffer {
+			if polygon.Contains(point) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, radius := range []geo.Meters{500, 1500} {
+		buffer := geo.BufferPoints(points, radius, 64)
+		assert.NotEmpty(t, buffer)
+
+		for _, point := range points {
+			assert.True(t, contains(buffer, point))
+			for _, bearing := range []geo.Degrees{0, 45, 90, 135, 180, 225, 270, 315} {
+				assert.True(t, contains(buffer, geo.DestinationPoint(point, bearing, radius/2)))
+			}
+		}
+
+		// Well past the radius of the nearest point is outside.
+		assert.False(t, contains(buffer, geo.DestinationPoint(points[0], 0, 2*radius)))
+	}
+
+	// At 500m, none of the circles touch, and at 1500m, they all run
+	// together.
+	assert.Len(t, geo.BufferPoints(points, 500, 64), 4)
+	assert.Len(t, geo.BufferPoints(points, 1500, 64), 1)
+
+	// Points further apart than twice the radius don't cover the gap between
+	// them.
+	var (
+		a = geo.LLA{Latitude: 38.9, Longitude: -77.05}
+		b = geo.DestinationPoint(a, 90, 10000)
+	)
+	apart := geo.BufferPoints([]geo.LLA{a, b}, 500, 64)
+	assert.Len(t, apart, 2)
+	assert.False(t, contains(apart, geo.DestinationPoint(a, 90, 5000)))
+
+	// But closer together, the gap is covered, and the far sides aren't.
+	b = geo.DestinationPoint(a, 90, 800)
+	merged := geo.BufferPoints([]geo.LLA{a, b, a}, 500, 64)
+	assert.Len(t, merged, 1)
+	assert.True(t, contains(merged, geo.DestinationPoint(a, 90, 400)))
+	assert.False(t, contains(merged, geo.DestinationPoint(a, 270, 600)))
+	assert.False(t, contains(merged, geo.DestinationPoint(b, 90, 600)))
+	assert.False(t, contains(merged, geo.DestinationPoint(geo.DestinationPoint(a, 90, 400), 0, 400)))
+
+	// A single point is just a circle.
+	single := geo.BufferPoints([]geo.LLA{a}, 500, 64)
+	assert.Len(t, single, 1)
+	assert.Len(t, single[0], 64)
+	assert.InEpsilon(t, geo.CirclePolygon(a, 500, 64).Area().F64(), single[0].Area().F64(), 1e-2)
+
+	assert.Nil(t, geo.BufferPoints(nil, 500, 64))
+	assert.Nil(t, geo.BufferPoints(points, 0, 64))
+	assert.Nil(t, geo.BufferPoints(points, 500, 2))
+}
+
+func TestBearingSpokes(t *testing.T) {
+	center := geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+
+	spokes := geo.BearingSpokes(center, 1000, 4)
+	assert.Len(t, spokes, 4)
+
+	for i, bearing := range []float64{0, 90, 180, 270} {
+		distance, err := geo.HaversineDistance(center, spokes[i])
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 1000, distance.F64(), 1e-9)
+		assert.InDelta(t, bearing, geo.InitialBearing(center, spokes[i]).F64(), 1e-9)
+	}
+
+	// North and South are along the meridian, with East and West either
+	// side of it.
+	assert.InDelta(t, center.Longitude.F64(), spokes[0].Longitude.F64(), 1e-12)
+	assert.InDelta(t, center.Longitude.F64(), spokes[2].Longitude.F64(), 1e-12)
+	assert.True(t, spokes[1].Longitude > center.Longitude)
+	assert.True(t, spokes[3].Longitude < center.Longitude)
+
+	assert.Nil(t, geo.BearingSpokes(center, 1000, 0))
+}
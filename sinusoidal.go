@@ -0,0 +1,63 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// LLAToSinusoidal will project the LLA onto the sinusoidal (Sanson-Flamsteed)
+// projection centered on the provided central meridian, returning the x
+// (Easting) and y (Northing) in Meters.
+//
+// The sinusoidal projection is equal-area -- any region on the map has the
+// same area as it does on the Earth -- which makes it handy for global
+// gridded data, though shapes get badly sheared far from the central
+// meridian. This treats the Earth as a sphere (with the same radius
+// HaversineDistance uses), and ignores Altitude.
+func LLAToSinusoidal(lla LLA, centralMeridian Degrees) (Meters, Meters) {
+	var (
+		phi    = lla.Latitude.Radians().F64()
+		lambda = normalizeLongitude(lla.Longitude - centralMeridian).Radians().F64()
+	)
+	return Meters(earthRadiusMeters * lambda * math.Cos(phi)), Meters(earthRadiusMeters * phi)
+}
+
+// SinusoidalToLLA will invert LLAToSinusoidal, returning the LLA of the
+// x (Easting) and y (Northing) on the sinusoidal projection centered on the
+// provided central meridian. The returned Altitude is 0.
+//
+// At the poles every Longitude is the same point, so the central meridian
+// is returned.
+func SinusoidalToLLA(x, y Meters, centralMeridian Degrees) LLA {
+	var (
+		phi = y.F64() / earthRadiusMeters
+		cos = math.Cos(phi)
+		lla = LLA{Latitude: Radians(phi).Degrees(), Longitude: centralMeridian}
+	)
+
+	if cos > 1e-12 {
+		lla.Longitude = normalizeLongitude(centralMeridian + Radians(x.F64()/(earthRadiusMeters*cos)).Degrees())
+	}
+	return lla
+}
+
+// vim: foldmethod=marker
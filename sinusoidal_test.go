@@ -0,0 +1,60 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSinusoidalCentralMeridian(t *testing.T) {
+	for _, lat := range []geo.Degrees{-60, 0.5, 38.897957, 80} {
+		x, y := geo.LLAToSinusoidal(geo.LLA{Latitude: lat, Longitude: -77}, -77)
+		assert.InDelta(t, 0, x.F64(), 1e-9)
+		assert.InEpsilon(t, lat.Radians().F64()*6371000, y.F64(), 1e-12)
+	}
+}
+
+func TestSinusoidalRoundTrip(t *testing.T) {
+	for _, lla := range []geo.LLA{
+		{Latitude: 38.897957, Longitude: -77.036560},
+		{Latitude: -33.8688, Longitude: 151.2093},
+		{Latitude: 10, Longitude: 179.5},
+	} {
+		x, y := geo.LLAToSinusoidal(lla, -150)
+		back := geo.SinusoidalToLLA(x, y, -150)
+		assert.InDelta(t, lla.Latitude.F64(), back.Latitude.F64(), 1e-9)
+		assert.InDelta(t, lla.Longitude.F64(), back.Longitude.F64(), 1e-9)
+	}
+
+	pole := geo.SinusoidalToLLA(0, geo.Meters(math.Pi/2*6371000), 20)
+	assert.InEpsilon(t, 90, pole.Latitude.F64(), 1e-12)
+	assert.Equal(t, geo.Degrees(20), pole.Longitude)
+}
+
+func TestSinusoidalEqualArea(t *testing.T) {
+	// Walk around the edge of a box, with plenty of points along the
+	// meridians (which are curved in the projection), and compare the area
+	// on the map to the area on the sphere.
+	var (
+		south, west, north, east = 10.0, 20.0, 40.0, 45.0
+		ring                     []geo.LLA
+		steps                    = 1000
+	)
+	for i := 0; i <= steps; i++ {
+		ring = append(ring, geo.LLA{Latitude: geo.Degrees(south + (north-south)*float64(i)/float64(steps)), Longitude: geo.Degrees(east)})
+	}
+	for i := 0; i <= steps; i++ {
+		ring = append(ring, geo.LLA{Latitude: geo.Degrees(north - (north-south)*float64(i)/float64(steps)), Longitude: geo.Degrees(west)})
+	}
+
+	var area float64
+	for i := range ring {
+		ax, ay := geo.LLAToSinusoidal(ring[i], 30)
+		bx, by := geo.LLAToSinusoidal(ring[(i+1)%len(ring)], 30)
+		area += ax.F64()*by.F64() - bx.F64()*ay.F64()
+	}
+	assert.InEpsilon(t, boxArea(south, west, north, east), math.Abs(area/2), 1e-5)
+}
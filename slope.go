@@ -0,0 +1,72 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// Slope will return the grade (rise over run, as a fraction -- so 0.1 is a
+// 10% grade) and the angle of the segment from a to b. The rise is the
+// difference in Altitude, and the run is the HaversineDistance between the
+// two points along the surface, so a positive grade means b is higher than a.
+//
+// If the two points have the same Latitude and Longitude, the slope is
+// undefined (the segment is vertical), and an error is returned.
+func Slope(a, b LLA) (float64, Degrees, error) {
+	run, err := HaversineDistance(
+		LLA{Latitude: a.Latitude, Longitude: a.Longitude},
+		LLA{Latitude: b.Latitude, Longitude: b.Longitude},
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	if run == 0 {
+		return 0, 0, fmt.Errorf("geo.Slope: points have no horizontal distance between them")
+	}
+
+	rise := (b.Altitude - a.Altitude).F64()
+	return rise / run.F64(), Radians(math.Atan2(rise, run.F64())).Degrees(), nil
+}
+
+// SlopeDistance will return the length of the straight slope from a to b,
+// which is the hypotenuse of the HaversineDistance between the two points
+// along the surface, and the difference in their Altitudes. This is the
+// "how much cable do I need" distance, assuming a constant slope between the
+// two points.
+//
+// If either Latitude is not within [-90, 90], an error is returned.
+func SlopeDistance(a, b LLA) (Meters, error) {
+	for _, point := range []LLA{a, b} {
+		if err := checkLatitude("geo.SlopeDistance", point.Latitude); err != nil {
+			return 0, err
+		}
+	}
+
+	var (
+		run  = haversine(a, b).F64()
+		rise = (b.Altitude - a.Altitude).F64()
+	)
+	return Meters(math.Hypot(run, rise)), nil
+}
+
+// vim: foldmethod=marker
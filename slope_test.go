@@ -0,0 +1,63 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlope(t *testing.T) {
+	// 1000m North along the sphere HaversineDistance uses.
+	a := geo.LLA{Latitude: 10, Longitude: 20, Altitude: 50}
+	b := geo.LLA{
+		Latitude:  a.Latitude + geo.Radians(1000.0/6371000).Degrees(),
+		Longitude: 20,
+		Altitude:  150,
+	}
+
+	grade, angle, err := geo.Slope(a, b)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 0.1, grade, 1e-6)
+	assert.InEpsilon(t, 5.7105931, angle.F64(), 1e-6)
+
+	grade, angle, err = geo.Slope(b, a)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, -0.1, grade, 1e-6)
+	assert.InEpsilon(t, -5.7105931, angle.F64(), 1e-6)
+}
+
+func TestSlopeVertical(t *testing.T) {
+	a := geo.LLA{Latitude: 10, Longitude: 20}
+	b := geo.LLA{Latitude: 10, Longitude: 20, Altitude: 100}
+
+	_, _, err := geo.Slope(a, b)
+	assert.Error(t, err)
+}
+
+func TestSlopeDistance(t *testing.T) {
+	// 3km North along the sphere HaversineDistance uses, and 4km up.
+	a := geo.LLA{Latitude: 10, Longitude: 20, Altitude: 50}
+	b := geo.LLA{
+		Latitude:  a.Latitude + geo.Radians(3000.0/6371000).Degrees(),
+		Longitude: 20,
+		Altitude:  4050,
+	}
+
+	distance, err := geo.SlopeDistance(a, b)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 5000, distance.F64(), 1e-9)
+
+	distance, err = geo.SlopeDistance(b, a)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 5000, distance.F64(), 1e-9)
+
+	// Straight up is just the difference in Altitude.
+	distance, err = geo.SlopeDistance(a, geo.LLA{Latitude: 10, Longitude: 20, Altitude: 150})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 100, distance.F64(), 1e-12)
+
+	_, err = geo.SlopeDistance(a, geo.LLA{Latitude: 91})
+	assert.Error(t, err)
+}
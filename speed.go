@@ -0,0 +1,90 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Speed represents a speed, in Meters per second.
+type Speed float64
+
+// F64 will return the value as a float64. Doing "value.F64()" is the same
+// as doing "float64(value)", except this can be a bit more clean at times.
+func (s Speed) F64() float64 {
+	return float64(s)
+}
+
+// maxTravelSeconds is the longest time (in seconds) that fits into a
+// time.Duration, which is a bit over 292 years.
+const maxTravelSeconds = math.MaxInt64 / float64(time.Second)
+
+// travelTime will return how long it takes to cover the distance at the
+// speed, or an error (prefixed with the provided name of the function) if
+// the speed isn't positive, or if it'd take too long to fit into a
+// time.Duration.
+func travelTime(name string, distance Meters, speed Speed) (time.Duration, error) {
+	if speed <= 0 {
+		return 0, fmt.Errorf("%s: Speed must be positive", name)
+	}
+	seconds := distance.F64() / speed.F64()
+	if seconds > maxTravelSeconds {
+		return 0, fmt.Errorf("%s: travel time is too long for a time.Duration", name)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// TravelTime will return how long it takes to get from a to b along a great
+// circle, going at the provided speed, such as for an ETA display.
+//
+// This uses HaversineDistance, and just like HaversineDistance, this will
+// return an error if either of the provided geo.LLA structs have an Altitude
+// other than 0. An error is also returned if the speed isn't positive, or if
+// the trip would take longer than a time.Duration can hold.
+func TravelTime(a, b LLA, speed Speed) (time.Duration, error) {
+	distance, err := HaversineDistance(a, b)
+	if err != nil {
+		return 0, fmt.Errorf("geo.TravelTime: %w", err)
+	}
+	return travelTime("geo.TravelTime", distance, speed)
+}
+
+// RhumbTravelTime will return how long it takes to get from a to b along a
+// rhumb line (holding a constant bearing, as a ship usually does), going at
+// the provided speed. See RhumbDistance for more about the distance. An
+// error is returned if the speed isn't positive, or if the trip would take
+// longer than a time.Duration can hold.
+func RhumbTravelTime(a, b LLA, speed Speed) (time.Duration, error) {
+	return travelTime("geo.RhumbTravelTime", RhumbDistance(a, b), speed)
+}
+
+// Isochrone will return a Polygon of everywhere that can be reached from the
+// origin within the duration, going at the provided speed in a straight line
+// (along a great circle) -- which is to say, a CirclePolygon with a radius of
+// the speed times the duration. See CirclePolygon for more about the
+// segments. This doesn't know about roads or terrain, so it's the best case.
+func Isochrone(origin LLA, speed Speed, duration time.Duration, segments int) Polygon {
+	return CirclePolygon(origin, Meters(speed.F64()*duration.Seconds()), segments)
+}
+
+// vim: foldmethod=marker
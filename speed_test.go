@@ -0,0 +1,79 @@
+package geo_test
+
+import (
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTravelTime(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 0, Longitude: 0}
+		b = geo.LLA{Latitude: 0, Longitude: 1}
+	)
+
+	distance, err := geo.HaversineDistance(a, b)
+	assert.NoError(t, err)
+
+	// A degree along the Equator is a touch over 111km, so at 100 m/s
+	// that's a little over 18 and a half minutes.
+	duration, err := geo.TravelTime(a, b, 100)
+	assert.NoError(t, err)
+	assert.InDelta(t, distance.F64()/100, duration.Seconds(), 1e-6)
+	assert.InDelta(t, 18.5, duration.Minutes(), 0.1)
+
+	_, err = geo.TravelTime(a, b, 0)
+	assert.Error(t, err)
+	_, err = geo.TravelTime(a, b, -1)
+	assert.Error(t, err)
+	_, err = geo.TravelTime(a, geo.LLA{Altitude: 10}, 100)
+	assert.Error(t, err)
+
+	// Crawling along would take longer than a time.Duration can hold.
+	_, err = geo.TravelTime(a, b, 1e-9)
+	assert.Error(t, err)
+}
+
+func TestRhumbTravelTime(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 60, Longitude: -10}
+		b = geo.LLA{Latitude: 60, Longitude: 10}
+	)
+
+	rhumb, err := geo.RhumbTravelTime(a, b, 10)
+	assert.NoError(t, err)
+	assert.InDelta(t, geo.RhumbDistance(a, b).F64()/10, rhumb.Seconds(), 1e-6)
+
+	gc, err := geo.TravelTime(a, b, 10)
+	assert.NoError(t, err)
+	assert.Greater(t, rhumb, gc)
+
+	_, err = geo.RhumbTravelTime(a, b, 0)
+	assert.Error(t, err)
+	_, err = geo.RhumbTravelTime(a, b, 1e-9)
+	assert.Error(t, err)
+
+	still, err := geo.RhumbTravelTime(a, a, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), still)
+}
+
+func TestIsochrone(t *testing.T) {
+	var (
+		origin = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		reach  = geo.Isochrone(origin, 1.5, 10*time.Minute, 36)
+	)
+
+	assert.Len(t, reach, 36)
+	assert.True(t, reach.Contains(origin))
+	for _, vertex := range reach {
+		d, err := geo.HaversineDistance(origin, vertex)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 900, d.F64(), 1e-9)
+	}
+
+	assert.Nil(t, geo.Isochrone(origin, 1.5, 10*time.Minute, 2))
+}
@@ -0,0 +1,81 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// Sphere will return a CoordinateSystem for a perfect sphere of the provided
+// radius.
+//
+// Earth isn't a sphere, but the math is a whole lot simpler without any
+// eccentricity to worry about -- which is handy for teaching, testing, and
+// for bodies that are a lot closer to round than Earth is. Sphere(6371000)
+// is the same Earth that HaversineDistance uses.
+func Sphere(radius Meters) CoordinateSystem {
+	return sphere{radius: radius.F64()}
+}
+
+type sphere struct {
+	radius float64
+}
+
+func (s sphere) LLAToXYZ(l LLA) XYZ {
+	var (
+		lat = l.Latitude.Radians().F64()
+		lon = l.Longitude.Radians().F64()
+		r   = s.radius + l.Altitude.F64()
+	)
+
+	return XYZ{
+		X: Meters(r * math.Cos(lat) * math.Cos(lon)),
+		Y: Meters(r * math.Cos(lat) * math.Sin(lon)),
+		Z: Meters(r * math.Sin(lat)),
+	}
+}
+
+func (s sphere) XYZToLLA(x XYZ) LLA {
+	var (
+		v = x.vector()
+		p = math.Sqrt(v.x*v.x + v.y*v.y)
+	)
+
+	return LLA{
+		Latitude:  Radians(math.Atan2(v.z, p)).Degrees(),
+		Longitude: Radians(math.Atan2(v.y, v.x)).Degrees(),
+		Altitude:  Meters(v.norm() - s.radius),
+	}
+}
+
+func (s sphere) LLAToENU(ref, lla LLA) ENU {
+	return s.XYZToENU(ref, s.LLAToXYZ(lla))
+}
+
+func (s sphere) XYZToENU(ref LLA, e XYZ) ENU {
+	return xyzToENU(ref, s.LLAToXYZ(ref), e)
+}
+
+func (s sphere) ENUToXYZ(ref LLA, e ENU) XYZ {
+	return enuToXYZ(ref, s.LLAToXYZ(ref), e)
+}
+
+// vim: foldmethod=marker
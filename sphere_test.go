@@ -0,0 +1,68 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSphereRoundTrip(t *testing.T) {
+	sphere := geo.Sphere(6371000)
+
+	for _, position := range []geo.LLA{
+		{Latitude: 38.8709455, Longitude: -77.0552551, Altitude: 100},
+		{Latitude: -33.8688, Longitude: 151.2093, Altitude: 0},
+		{Latitude: 89.9, Longitude: -179.9, Altitude: 400000},
+	} {
+		x := sphere.LLAToXYZ(position)
+		assert.InEpsilon(t, 6371000+position.Altitude.F64(), norm(x), 1e-12)
+
+		position1 := sphere.XYZToLLA(x)
+		assert.InEpsilon(t, position.Latitude.F64(), position1.Latitude.F64(), 1e-12)
+		assert.InEpsilon(t, position.Longitude.F64(), position1.Longitude.F64(), 1e-12)
+		assert.InDelta(t, position.Altitude.F64(), position1.Altitude.F64(), 1e-6)
+	}
+}
+
+func TestSphereXYZToLLAAltitude(t *testing.T) {
+	sphere := geo.Sphere(1000)
+
+	lla := sphere.XYZToLLA(geo.XYZ{X: 300, Y: 400, Z: 1200})
+	assert.InEpsilon(t, 300, lla.Altitude.F64(), 1e-12)
+	assert.InEpsilon(t, geo.Radians(math.Atan2(1200, 500)).Degrees().F64(), lla.Latitude.F64(), 1e-12)
+
+	surface := geo.NearestSurfacePoint(sphere, geo.XYZ{X: 300, Y: 400, Z: 1200})
+	assert.InEpsilon(t, 1000, norm(surface), 1e-12)
+}
+
+func TestSphereENU(t *testing.T) {
+	sphere := geo.Sphere(6371000)
+	ref := geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30}
+	position := geo.LLA{Latitude: 38.8709455, Longitude: -77.0552551, Altitude: 100}
+
+	enu := sphere.LLAToENU(ref, position)
+	position1 := geo.OffsetLLA(sphere, ref, enu)
+	assert.InEpsilon(t, position.Latitude.F64(), position1.Latitude.F64(), 1e-12)
+	assert.InEpsilon(t, position.Longitude.F64(), position1.Longitude.F64(), 1e-12)
+	assert.InEpsilon(t, position.Altitude.F64(), position1.Altitude.F64(), 1e-6)
+
+	above := geo.ENUToLLA(sphere, ref, geo.ENU{Up: 400000})
+	assert.InEpsilon(t, ref.Latitude.F64(), above.Latitude.F64(), 1e-12)
+	assert.InEpsilon(t, ref.Longitude.F64(), above.Longitude.F64(), 1e-12)
+	assert.InEpsilon(t, 400030, above.Altitude.F64(), 1e-9)
+
+	// Straight up on a sphere is straight out from the center.
+	up := sphere.ENUToXYZ(geo.LLA{Latitude: 10, Longitude: 20}, geo.ENU{Up: 100})
+	assert.InEpsilon(t, 6371100, norm(up), 1e-12)
+
+	ground, err := geo.RayToSurface(sphere, geo.LLA{Latitude: 10, Longitude: 20, Altitude: 1000}, geo.AER{Elevation: -90})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, 10, ground.Latitude.F64(), 1e-12)
+	assert.InEpsilon(t, 20, ground.Longitude.F64(), 1e-12)
+
+	_, err = geo.RayToSurface(sphere, geo.LLA{Latitude: 10, Longitude: 20, Altitude: 1000}, geo.AER{Elevation: 10})
+	assert.Error(t, err)
+}
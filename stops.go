@@ -0,0 +1,90 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"time"
+)
+
+// Stop is a stretch of a track where whatever was being tracked stayed put.
+type Stop struct {
+	// Location is the center of the points that make up the Stop.
+	Location LLA
+
+	// Start is the Time of the first point of the Stop.
+	Start time.Time
+
+	// End is the Time of the last point of the Stop.
+	End time.Time
+}
+
+// Duration will return how long the Stop lasted.
+func (s Stop) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// DetectStops will return every Stop along the track, where a Stop is a run
+// of consecutive points that all stay within maxRadius of the first point of
+// the run for at least minDuration.
+//
+// The track is walked from the start, and each run is made as long as it can
+// be before moving on, so Stops never overlap. Distances are along the
+// surface, using the same spherical Earth as HaversineDistance, and Altitude
+// is ignored for the distance (GPS Altitude is too noisy to be of any help
+// here), though the Location of each Stop has the mean Altitude of its
+// points. The track is expected to be in order of Time.
+func DetectStops(track []TrackPoint, maxRadius Meters, minDuration time.Duration) []Stop {
+	stops := []Stop{}
+
+	for i := 0; i < len(track); {
+		j := i + 1
+		for j < len(track) && haversine(track[i].Location, track[j].Location) <= maxRadius {
+			j++
+		}
+
+		if track[j-1].Time.Sub(track[i].Time) < minDuration {
+			i++
+			continue
+		}
+
+		var (
+			center   vector
+			altitude Meters
+		)
+		for _, point := range track[i:j] {
+			center = center.add(unitVector(point.Location))
+			altitude += point.Location.Altitude
+		}
+
+		location := center.unit().lla()
+		location.Altitude = altitude / Meters(j-i)
+
+		stops = append(stops, Stop{
+			Location: location,
+			Start:    track[i].Time,
+			End:      track[j-1].Time,
+		})
+		i = j
+	}
+	return stops
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,57 @@
+package geo_test
+
+import (
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectStops(t *testing.T) {
+	var (
+		start = time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC)
+		cafe  = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		track []geo.TrackPoint
+		now   = start
+	)
+
+	add := func(location geo.LLA) {
+		track = append(track, geo.TrackPoint{Location: location, Time: now})
+		now = now.Add(time.Minute)
+	}
+
+	// Walking North towards the cafe, 100m a minute.
+	for i := 10; i > 0; i-- {
+		add(geo.DestinationPoint(cafe, 180, geo.Meters(i*100)))
+	}
+
+	// Milling about for half an hour.
+	arrived := now
+	for i := 0; i < 30; i++ {
+		add(geo.DestinationPoint(cafe, geo.Degrees(i*45), 10))
+	}
+	left := now.Add(-time.Minute)
+
+	// And walking off East.
+	for i := 1; i <= 10; i++ {
+		add(geo.DestinationPoint(cafe, 90, geo.Meters(i*100)))
+	}
+
+	stops := geo.DetectStops(track, 25, 10*time.Minute)
+	assert.Len(t, stops, 1)
+
+	stop := stops[0]
+	assert.Equal(t, arrived, stop.Start)
+	assert.Equal(t, left, stop.End)
+	assert.Equal(t, 29*time.Minute, stop.Duration())
+
+	distance, err := geo.HaversineDistance(cafe, stop.Location)
+	assert.NoError(t, err)
+	assert.True(t, distance < 10)
+
+	// No lingering is long enough for a whole hour.
+	assert.Empty(t, geo.DetectStops(track, 25, time.Hour))
+	assert.Empty(t, geo.DetectStops(nil, 25, time.Minute))
+}
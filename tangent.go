@@ -0,0 +1,130 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// xyzToENU will rotate the offset of the XYZ from refXYZ (the XYZ of ref)
+// into the ENU tangent plane at the Latitude and Longitude of ref. The
+// Latitude is the angle of the surface normal, so this works for any
+// CoordinateSystem, spherical or not.
+func xyzToENU(ref LLA, refXYZ, e XYZ) ENU {
+	var (
+		lambda = ref.Latitude.Radians().F64()
+		phi    = ref.Longitude.Radians().F64()
+
+		sinLambda = math.Sin(lambda)
+		cosLambda = math.Cos(lambda)
+		sinPhi    = math.Sin(phi)
+		cosPhi    = math.Cos(phi)
+
+		xd = (e.X - refXYZ.X).F64()
+		yd = (e.Y - refXYZ.Y).F64()
+		zd = (e.Z - refXYZ.Z).F64()
+	)
+
+	return ENU{
+		East:  Meters(-sinPhi*xd + cosPhi*yd),
+		North: Meters(-cosPhi*sinLambda*xd - sinLambda*sinPhi*yd + cosLambda*zd),
+		Up:    Meters(cosLambda*cosPhi*xd + cosLambda*sinPhi*yd + sinLambda*zd),
+	}
+}
+
+// enuToXYZ will rotate the ENU out of the tangent plane at the Latitude and
+// Longitude of ref, and add it to refXYZ (the XYZ of ref). This is the
+// inverse of xyzToENU.
+func enuToXYZ(ref LLA, refXYZ XYZ, e ENU) XYZ {
+	var (
+		lambda = ref.Latitude.Radians().F64()
+		phi    = ref.Longitude.Radians().F64()
+
+		sinLambda = math.Sin(lambda)
+		cosLambda = math.Cos(lambda)
+
+		sinPhi = math.Sin(phi)
+		cosPhi = math.Cos(phi)
+
+		east  = e.East.F64()
+		north = e.North.F64()
+		up    = e.Up.F64()
+
+		xd = -sinPhi*east - cosPhi*sinLambda*north + cosLambda*cosPhi*up
+		yd = cosPhi*east - sinLambda*sinPhi*north + cosLambda*sinPhi*up
+		zd = cosLambda*north + sinLambda*up
+	)
+
+	return XYZ{
+		X: refXYZ.X + Meters(xd),
+		Y: refXYZ.Y + Meters(yd),
+		Z: refXYZ.Z + Meters(zd),
+	}
+}
+
+// enuBasis will return the East, North and Up unit vectors of the tangent
+// plane at the Latitude and Longitude of ref, in XYZ space. These are the
+// columns of the rotation enuToXYZ does.
+func enuBasis(ref LLA) (vector, vector, vector) {
+	var (
+		lambda = ref.Latitude.Radians().F64()
+		phi    = ref.Longitude.Radians().F64()
+
+		sinLambda = math.Sin(lambda)
+		cosLambda = math.Cos(lambda)
+		sinPhi    = math.Sin(phi)
+		cosPhi    = math.Cos(phi)
+	)
+
+	return vector{x: -sinPhi, y: cosPhi, z: 0},
+		vector{x: -cosPhi * sinLambda, y: -sinLambda * sinPhi, z: cosLambda},
+		vector{x: cosLambda * cosPhi, y: cosLambda * sinPhi, z: sinLambda}
+}
+
+// ENUToLLA will take a relative ENU in the tangent plane at the provided
+// reference LLA, and return the LLA of that point in the CoordinateSystem.
+// This is accurate even for very large Up components, such as the position
+// of a high-altitude target.
+func ENUToLLA(cs CoordinateSystem, ref LLA, e ENU) LLA {
+	return cs.XYZToLLA(cs.ENUToXYZ(ref, e))
+}
+
+// OffsetLLA will return the LLA that is the provided ENU displacement away
+// from the origin LLA, in the origin's tangent plane.
+func OffsetLLA(cs CoordinateSystem, origin LLA, d ENU) LLA {
+	return cs.XYZToLLA(cs.ENUToXYZ(origin, d))
+}
+
+// ENUBasis will return the East, North and Up unit vectors of the ENU
+// tangent plane at the reference LLA, in the XYZ space of the
+// CoordinateSystem. These are the columns of the rotation that ENUToXYZ does
+// (and the rows of the rotation that XYZToENU does), and Up is the surface
+// normal at the LLA.
+//
+// The tangent plane only depends on the Latitude and Longitude of the LLA
+// (the Latitude being the angle of the surface normal), so the basis is the
+// same in every CoordinateSystem.
+func ENUBasis(cs CoordinateSystem, ref LLA) (east, north, up XYZ) {
+	e, n, u := enuBasis(ref)
+	return e.xyz(), n.xyz(), u.xyz()
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,264 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TrackPoint is a single fix along a track -- where something was, and when
+// it was there.
+type TrackPoint struct {
+	Location LLA
+	Time     time.Time
+}
+
+// mergeEpsilon is how close (in a straight line, including Altitude) two
+// consecutive points can be before MergeTracks considers them to be the
+// same point.
+const mergeEpsilon Meters = 0.01
+
+// ElevationProfile will return the total elevation gain and loss along the
+// track, which is the sum of all the increases (and decreases) in Altitude
+// from point to point. Both values are positive.
+//
+// Raw GPS Altitude tends to be noisy, which will massively overstate the
+// gain and loss of a track. ElevationProfileWithThreshold can be used to
+// ignore changes smaller than the noise.
+func ElevationProfile(track []LLA) (Meters, Meters) {
+	return ElevationProfileWithThreshold(track, 0)
+}
+
+// ElevationProfileWithThreshold will return the total elevation gain and loss
+// along the track, ignoring changes in Altitude that are not more than the
+// threshold.
+//
+// Changes are measured from the last point that was counted rather than from
+// the previous point, so a slow and steady climb made up of many small steps
+// will still be counted once it adds up to more than the threshold, while
+// jitter back and forth within the threshold is ignored.
+func ElevationProfileWithThreshold(track []LLA, threshold Meters) (Meters, Meters) {
+	var gain, loss Meters
+	if len(track) == 0 {
+		return gain, loss
+	}
+
+	reference := track[0].Altitude
+	for _, point := range track[1:] {
+		delta := point.Altitude - reference
+		switch {
+		case delta > threshold:
+			gain += delta
+		case -delta > threshold:
+			loss -= delta
+		default:
+			continue
+		}
+		reference = point.Altitude
+	}
+	return gain, loss
+}
+
+// UnwrapLongitudes will return a copy of the path, with 360 Degrees added to
+// (or removed from) the Longitudes as needed so that each point's Longitude
+// is within 180 Degrees of the point before it.
+//
+// This is handy when plotting a path on a flat chart, since a path crossing
+// the anti-meridian will otherwise jump from one side of the chart to the
+// other. The returned Longitudes may well be outside of [-180, 180) -- use
+// WrapLongitudes to bring them back.
+func UnwrapLongitudes(path []LLA) []LLA {
+	unwrapped := make([]LLA, len(path))
+	copy(unwrapped, path)
+
+	for i := 1; i < len(unwrapped); i++ {
+		delta := normalizeLongitude(path[i].Longitude - path[i-1].Longitude)
+		unwrapped[i].Longitude = unwrapped[i-1].Longitude + delta
+	}
+	return unwrapped
+}
+
+// WrapLongitudes will return a copy of the path, with every Longitude wrapped
+// to be within [-180, 180).
+func WrapLongitudes(path []LLA) []LLA {
+	wrapped := make([]LLA, len(path))
+	for i, point := range path {
+		point.Longitude = normalizeLongitude(point.Longitude)
+		wrapped[i] = point
+	}
+	return wrapped
+}
+
+// MergeTracks will concatenate the tracks, in order, into a single track,
+// dropping any point that's within a centimeter of the point before it. This
+// takes care of the duplicate points that tend to show up where one GPS log
+// ends and the next begins (as well as any duplicates within a track).
+//
+// Points are never reordered, and the input tracks are not modified.
+func MergeTracks(tracks ...[]LLA) []LLA {
+	var (
+		wgs84    = WGS84()
+		merged   = []LLA{}
+		previous XYZ
+	)
+
+	for _, track := range tracks {
+		for _, point := range track {
+			x := wgs84.LLAToXYZ(point)
+			if len(merged) > 0 && xyzDistance(previous, x) < mergeEpsilon {
+				continue
+			}
+			merged = append(merged, point)
+			previous = x
+		}
+	}
+	return merged
+}
+
+// DownsampleByDistance will return a thinned out copy of the track, only
+// keeping points that are at least minSpacing away from the last point kept.
+// The first and last points are always kept, so the thinned track starts and
+// ends in the same place the original did.
+//
+// This is a lot cheaper (and a lot less clever) than simplifying the track
+// by its shape -- it's best for evening out logs that were recorded far more
+// often than was needed. Distances are along the surface, using the same
+// spherical Earth as HaversineDistance, and ignore Altitude.
+func DownsampleByDistance(track []LLA, minSpacing Meters) []LLA {
+	if len(track) < 3 {
+		downsampled := make([]LLA, len(track))
+		copy(downsampled, track)
+		return downsampled
+	}
+
+	downsampled := []LLA{track[0]}
+	for _, point := range track[1 : len(track)-1] {
+		if haversine(downsampled[len(downsampled)-1], point) >= minSpacing {
+			downsampled = append(downsampled, point)
+		}
+	}
+	return append(downsampled, track[len(track)-1])
+}
+
+// SplitByDistance will return a copy of the path, with points added along
+// any leg that's longer than maxLeg, so that no leg is longer than maxLeg.
+// A long leg is split up into as few equal length legs as will do, with the
+// new points placed along the great circle using IntermediatePoint (which
+// also interpolates the Altitude).
+//
+// Distances are along the surface, using the same spherical Earth as
+// HaversineDistance, and ignore Altitude. If maxLeg isn't positive, the path
+// is returned as-is.
+func SplitByDistance(path []LLA, maxLeg Meters) []LLA {
+	if maxLeg <= 0 || len(path) < 2 {
+		split := make([]LLA, len(path))
+		copy(split, path)
+		return split
+	}
+
+	split := []LLA{path[0]}
+	for i := 1; i < len(path); i++ {
+		var (
+			a    = path[i-1]
+			b    = path[i]
+			legs = int(math.Ceil((haversine(a, b) / maxLeg).F64()))
+		)
+		for j := 1; j < legs; j++ {
+			split = append(split, IntermediatePoint(a, b, float64(j)/float64(legs)))
+		}
+		split = append(split, b)
+	}
+	return split
+}
+
+// ArcLengthParam will return a function that maps t, from 0 (the start of
+// the path) to 1 (the end of the path), to the point that fraction of the
+// total distance along the path. Unlike stepping from vertex to vertex, this
+// moves at a constant speed no matter how unevenly the vertices are spaced,
+// which is what's wanted when animating a marker along a route.
+//
+// Values of t outside of [0, 1] are clamped. Each leg is a great circle, with
+// points placed using IntermediatePoint (which also interpolates the
+// Altitude), and distances are along the surface, using the same spherical
+// Earth as HaversineDistance. An empty path always returns the zero LLA.
+func ArcLengthParam(path []LLA) func(t float64) LLA {
+	if len(path) == 0 {
+		return func(float64) LLA { return LLA{} }
+	}
+
+	// cumulative[i] is the distance along the path to path[i].
+	cumulative := make([]Meters, len(path))
+	for i := 1; i < len(path); i++ {
+		cumulative[i] = cumulative[i-1] + haversine(path[i-1], path[i])
+	}
+	total := cumulative[len(cumulative)-1]
+
+	return func(t float64) LLA {
+		if total == 0 || t <= 0 {
+			return path[0]
+		}
+		if t >= 1 {
+			return path[len(path)-1]
+		}
+
+		var (
+			target = Meters(t) * total
+			i      = sort.Search(len(cumulative), func(i int) bool { return cumulative[i] >= target })
+			leg    = cumulative[i] - cumulative[i-1]
+		)
+		return IntermediatePoint(path[i-1], path[i], ((target - cumulative[i-1]) / leg).F64())
+	}
+}
+
+// HeadingSeries will return the heading (the InitialBearing, in Degrees
+// clockwise from North) of each leg of the track, and the turn rate (in
+// Degrees per second, positive for turns to the right) between each pair of
+// legs.
+//
+// There's one fewer heading than points, and one fewer turn rate than
+// headings. The turn rate is the change in heading (going the short way
+// around, so a turn from 350 to 10 is 20 Degrees to the right), over the
+// time between the middles of the two legs. If no time passed, the turn rate
+// is 0.
+func HeadingSeries(track []TrackPoint) ([]Degrees, []Degrees) {
+	if len(track) < 2 {
+		return []Degrees{}, []Degrees{}
+	}
+
+	headings := make([]Degrees, len(track)-1)
+	for i := range headings {
+		headings[i] = InitialBearing(track[i].Location, track[i+1].Location)
+	}
+
+	turnRates := make([]Degrees, len(headings)-1)
+	for i := range turnRates {
+		seconds := track[i+2].Time.Sub(track[i].Time).Seconds() / 2
+		if seconds == 0 {
+			continue
+		}
+		turnRates[i] = normalizeLongitude(headings[i+1]-headings[i]) / Degrees(seconds)
+	}
+	return headings, turnRates
+}
+
+// vim: foldmethod=marker
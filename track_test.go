@@ -0,0 +1,253 @@
+package geo_test
+
+import (
+	"testing"
+	"time"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func altitudes(alts ...geo.Meters) []geo.LLA {
+	track := make([]geo.LLA, len(alts))
+	for i, alt := range alts {
+		track[i] = geo.LLA{Latitude: 10, Longitude: geo.Degrees(i) / 1000, Altitude: alt}
+	}
+	return track
+}
+
+func TestElevationProfile(t *testing.T) {
+	gain, loss := geo.ElevationProfile(altitudes(0, 100, 50, 150))
+	assert.Equal(t, geo.Meters(200), gain)
+	assert.Equal(t, geo.Meters(50), loss)
+
+	gain, loss = geo.ElevationProfile(nil)
+	assert.Equal(t, geo.Meters(0), gain)
+	assert.Equal(t, geo.Meters(0), loss)
+}
+
+func TestElevationProfileWithThreshold(t *testing.T) {
+	// Up 100, down 50, up 100, with a few meters of noise on top.
+	track := altitudes(0, 2, 0, 3, 100, 98, 101, 50, 52, 49, 150, 148)
+
+	gain, loss := geo.ElevationProfile(track)
+	assert.Equal(t, geo.Meters(208), gain)
+	assert.Equal(t, geo.Meters(60), loss)
+
+	gain, loss = geo.ElevationProfileWithThreshold(track, 5)
+	assert.Equal(t, geo.Meters(200), gain)
+	assert.Equal(t, geo.Meters(50), loss)
+}
+
+func TestUnwrapLongitudes(t *testing.T) {
+	path := []geo.LLA{
+		{Latitude: 10, Longitude: 177},
+		{Latitude: 11, Longitude: 179},
+		{Latitude: 12, Longitude: -179},
+		{Latitude: 13, Longitude: -177},
+		{Latitude: 14, Longitude: -175},
+	}
+
+	unwrapped := geo.UnwrapLongitudes(path)
+	assert.Len(t, unwrapped, len(path))
+	for i, point := range unwrapped {
+		assert.Equal(t, path[i].Latitude, point.Latitude)
+		if i > 0 {
+			assert.InEpsilon(t, 2, (point.Longitude - unwrapped[i-1].Longitude).F64(), 1e-9)
+		}
+	}
+	assert.InEpsilon(t, 185, unwrapped[4].Longitude.F64(), 1e-9)
+
+	// The original path is left alone.
+	assert.Equal(t, geo.Degrees(-175), path[4].Longitude)
+
+	wrapped := geo.WrapLongitudes(unwrapped)
+	for i, point := range wrapped {
+		assert.InDelta(t, path[i].Longitude.F64(), point.Longitude.F64(), 1e-9)
+	}
+}
+
+func TestWrapLongitudes(t *testing.T) {
+	wrapped := geo.WrapLongitudes([]geo.LLA{
+		{Longitude: 190},
+		{Longitude: -190},
+		{Longitude: 540},
+		{Longitude: 45},
+	})
+	assert.Equal(t, geo.Degrees(-170), wrapped[0].Longitude)
+	assert.Equal(t, geo.Degrees(170), wrapped[1].Longitude)
+	assert.Equal(t, geo.Degrees(-180), wrapped[2].Longitude)
+	assert.Equal(t, geo.Degrees(45), wrapped[3].Longitude)
+}
+
+func TestMergeTracks(t *testing.T) {
+	var (
+		a = []geo.LLA{
+			{Latitude: 10, Longitude: 20},
+			{Latitude: 10.001, Longitude: 20},
+			{Latitude: 10.002, Longitude: 20},
+		}
+		b = []geo.LLA{
+			{Latitude: 10.002, Longitude: 20},
+			{Latitude: 10.003, Longitude: 20},
+		}
+		c = []geo.LLA{
+			// A hair off of the end of b, which is still the same point.
+			{Latitude: 10.003, Longitude: 20.00000001},
+			{Latitude: 10.004, Longitude: 20},
+		}
+	)
+
+	merged := geo.MergeTracks(a, b, c)
+	assert.Equal(t, []geo.LLA{
+		{Latitude: 10, Longitude: 20},
+		{Latitude: 10.001, Longitude: 20},
+		{Latitude: 10.002, Longitude: 20},
+		{Latitude: 10.003, Longitude: 20},
+		{Latitude: 10.004, Longitude: 20},
+	}, merged)
+
+	// Same place, but a different Altitude, is not a duplicate.
+	merged = geo.MergeTracks(
+		[]geo.LLA{{Latitude: 10, Longitude: 20}},
+		[]geo.LLA{{Latitude: 10, Longitude: 20, Altitude: 5}},
+	)
+	assert.Len(t, merged, 2)
+
+	assert.Empty(t, geo.MergeTracks())
+}
+
+func TestDownsampleByDistance(t *testing.T) {
+	var (
+		start = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		track []geo.LLA
+	)
+
+	// A point every meter, for a kilometer and a half, heading North.
+	for i := 0; i <= 1500; i++ {
+		track = append(track, geo.DestinationPoint(start, 0, geo.Meters(i)))
+	}
+
+	// Just under 100m, since the points won't be *exactly* a meter apart.
+	downsampled := geo.DownsampleByDistance(track, 99.5)
+	assert.Len(t, downsampled, 16)
+	assert.Equal(t, track[0], downsampled[0])
+	assert.Equal(t, track[len(track)-1], downsampled[len(downsampled)-1])
+
+	for i := 1; i < len(downsampled); i++ {
+		spacing, err := geo.HaversineDistance(downsampled[i-1], downsampled[i])
+		assert.NoError(t, err)
+		assert.InDelta(t, 100, spacing.F64(), 1e-6)
+	}
+
+	// The last point is kept, even though it's too close to the one before.
+	short := geo.DownsampleByDistance(track[:1051], 99.5)
+	assert.Len(t, short, 12)
+	assert.Equal(t, track[1050], short[11])
+
+	assert.Len(t, geo.DownsampleByDistance(track[:2], 100), 2)
+	assert.Empty(t, geo.DownsampleByDistance(nil, 100))
+}
+
+func TestSplitByDistance(t *testing.T) {
+	var (
+		a = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 100}
+		b = geo.DestinationPoint(a, 60, 10000)
+		c = geo.DestinationPoint(b, 0, 500)
+	)
+	b.Altitude = 200
+	c.Altitude = 200
+
+	split := geo.SplitByDistance([]geo.LLA{a, b, c}, 3000)
+
+	// 10km takes 4 legs of 2.5km, and the 500m leg is left alone.
+	assert.Len(t, split, 6)
+	assert.Equal(t, a, split[0])
+	assert.Equal(t, b, split[4])
+	assert.Equal(t, c, split[5])
+
+	for i := 1; i < 5; i++ {
+		distance, err := geo.HaversineDistance(
+			geo.LLA{Latitude: split[i-1].Latitude, Longitude: split[i-1].Longitude},
+			geo.LLA{Latitude: split[i].Latitude, Longitude: split[i].Longitude},
+		)
+		assert.NoError(t, err)
+		assert.InEpsilon(t, 2500, distance.F64(), 1e-6)
+	}
+	assert.InEpsilon(t, 125, split[1].Altitude.F64(), 1e-9)
+
+	assert.Len(t, geo.SplitByDistance([]geo.LLA{a, b}, 0), 2)
+	assert.Empty(t, geo.SplitByDistance(nil, 100))
+}
+
+func TestHeadingSeries(t *testing.T) {
+	var (
+		center = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		start  = time.Date(2021, 3, 14, 15, 9, 26, 0, time.UTC)
+		track  []geo.TrackPoint
+	)
+
+	// Going clockwise around a circle, 10 Degrees a second, which is a
+	// constant turn to the right, all the way around (and through North).
+	for i := 0; i < 40; i++ {
+		track = append(track, geo.TrackPoint{
+			Location: geo.DestinationPoint(center, geo.Degrees(i*10), 1000),
+			Time:     start.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	headings, turnRates := geo.HeadingSeries(track)
+	assert.Len(t, headings, 39)
+	assert.Len(t, turnRates, 38)
+
+	// The first leg (from bearing 0 to 10 around the center) heads off
+	// just South of due East.
+	assert.InDelta(t, 95, headings[0].F64(), 0.01)
+	for _, rate := range turnRates {
+		assert.InDelta(t, 10, rate.F64(), 0.01)
+	}
+
+	// And the other way around is a turn to the left.
+	for i, j := 0, len(track)-1; i < j; i, j = i+1, j-1 {
+		track[i].Location, track[j].Location = track[j].Location, track[i].Location
+	}
+	_, turnRates = geo.HeadingSeries(track)
+	for _, rate := range turnRates {
+		assert.InDelta(t, -10, rate.F64(), 0.01)
+	}
+
+	headings, turnRates = geo.HeadingSeries(track[:1])
+	assert.Empty(t, headings)
+	assert.Empty(t, turnRates)
+}
+
+func TestArcLengthParam(t *testing.T) {
+	var (
+		// One short leg, and one long leg, along the Equator.
+		path = []geo.LLA{
+			{Latitude: 0, Longitude: 0},
+			{Latitude: 0, Longitude: 1},
+			{Latitude: 0, Longitude: 4},
+		}
+		param = geo.ArcLengthParam(path)
+	)
+
+	// Half way along is half way along the distance, not the middle vertex.
+	half := param(0.5)
+	assert.InDelta(t, 0, half.Latitude.F64(), 1e-9)
+	assert.InDelta(t, 2, half.Longitude.F64(), 1e-9)
+
+	quarter := param(0.25)
+	assert.InDelta(t, 1, quarter.Longitude.F64(), 1e-9)
+
+	assert.Equal(t, path[0], param(0))
+	assert.Equal(t, path[0], param(-1))
+	assert.Equal(t, path[2], param(1))
+	assert.Equal(t, path[2], param(2))
+
+	assert.Equal(t, geo.LLA{}, geo.ArcLengthParam(nil)(0.5))
+
+	still := geo.ArcLengthParam([]geo.LLA{path[1], path[1]})
+	assert.Equal(t, path[1], still(0.5))
+}
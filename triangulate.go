@@ -0,0 +1,81 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"fmt"
+	"math"
+)
+
+// bearingVector will return the direction (as a unit vector, tangent to the
+// sphere) one would head off in from the LLA, at the provided bearing.
+func bearingVector(l LLA, bearing Degrees) vector {
+	var (
+		lat = l.Latitude.Radians().F64()
+		lon = l.Longitude.Radians().F64()
+		az  = bearing.Radians().F64()
+
+		north = vector{
+			x: -math.Sin(lat) * math.Cos(lon),
+			y: -math.Sin(lat) * math.Sin(lon),
+			z: math.Cos(lat),
+		}
+		east = vector{x: -math.Sin(lon), y: math.Cos(lon)}
+	)
+	return north.scale(math.Cos(az)).add(east.scale(math.Sin(az)))
+}
+
+// Triangulate will return where the two bearings (in Degrees clockwise from
+// North) from each of the observers cross, such as when two sensors each
+// have a line of bearing to the same emitter. Each bearing is followed along
+// a great circle on the same spherical Earth as HaversineDistance.
+//
+// Two great circles cross twice, on opposite sides of the Earth. The crossing
+// returned is the one that's ahead of both observers; if the bearings are
+// parallel (along the same great circle), or point away from each other such
+// that there's no crossing ahead of both, an error is returned. Altitude is
+// ignored, and the returned point has an Altitude of 0.
+func Triangulate(obs1 LLA, az1 Degrees, obs2 LLA, az2 Degrees) (LLA, error) {
+	var (
+		p1 = unitVector(obs1)
+		p2 = unitVector(obs2)
+		d1 = bearingVector(obs1, az1)
+		d2 = bearingVector(obs2, az2)
+
+		crossing = p1.cross(d1).cross(p2.cross(d2))
+	)
+
+	if crossing.norm() < 1e-12 {
+		return LLA{}, fmt.Errorf("geo.Triangulate: bearings are parallel")
+	}
+
+	crossing = crossing.unit()
+	if crossing.dot(d1)+crossing.dot(d2) < 0 {
+		crossing = crossing.scale(-1)
+	}
+
+	if crossing.dot(d1) <= 0 || crossing.dot(d2) <= 0 {
+		return LLA{}, fmt.Errorf("geo.Triangulate: bearings do not cross ahead of both observers")
+	}
+	return crossing.lla(), nil
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,52 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriangulate(t *testing.T) {
+	var (
+		target = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		obs1   = geo.LLA{Latitude: 38.8, Longitude: -77.2}
+		obs2   = geo.LLA{Latitude: 39.1, Longitude: -76.9}
+	)
+
+	found, err := geo.Triangulate(
+		obs1, geo.InitialBearing(obs1, target),
+		obs2, geo.InitialBearing(obs2, target),
+	)
+	assert.NoError(t, err)
+	assert.True(t, found.ApproxEqual(target, 1e-9, 0))
+
+	// Across the anti-meridian.
+	target = geo.LLA{Latitude: -10, Longitude: 179.5}
+	obs1 = geo.LLA{Latitude: -12, Longitude: -178}
+	obs2 = geo.LLA{Latitude: -8, Longitude: 177}
+
+	found, err = geo.Triangulate(
+		obs1, geo.InitialBearing(obs1, target),
+		obs2, geo.InitialBearing(obs2, target),
+	)
+	assert.NoError(t, err)
+	assert.True(t, found.ApproxEqual(target, 1e-9, 0))
+}
+
+func TestTriangulateErrors(t *testing.T) {
+	// Both looking North along the same meridian.
+	_, err := geo.Triangulate(
+		geo.LLA{Latitude: 10, Longitude: 20}, 0,
+		geo.LLA{Latitude: 20, Longitude: 20}, 0,
+	)
+	assert.Error(t, err)
+
+	// Looking away from each other.
+	_, err = geo.Triangulate(
+		geo.LLA{Latitude: 0, Longitude: 0}, 270,
+		geo.LLA{Latitude: 1, Longitude: 1}, 90,
+	)
+	assert.Error(t, err)
+}
@@ -35,6 +35,27 @@ func (m Meters) F64() float64 {
 	return float64(m)
 }
 
+// ApproxEqual will return true if the two distances are within the tolerance
+// of each other (inclusive).
+func (m Meters) ApproxEqual(other Meters, tolerance Meters) bool {
+	return math.Abs((m - other).F64()) <= tolerance.F64()
+}
+
+// Round will return the distance rounded to the provided number of decimal
+// places, with halfway values rounded away from zero.
+func (m Meters) Round(decimals int) Meters {
+	return Meters(roundDecimals(m.F64(), decimals))
+}
+
+// SquareMeters represents the SI unit of area, Meters squared.
+type SquareMeters float64
+
+// F64 will return the value as a float64. Doing "value.F64()" is the same
+// as doing "float64(value)", except this can be a bit more clean at times.
+func (m SquareMeters) F64() float64 {
+	return float64(m)
+}
+
 // Degrees represents an angular measurement, in Degrees. This type is for
 // two main reasons -- firstly, to enforce (on a type level) that the user is
 // aware that the angle measurements must be in Degrees, and second, to bind
@@ -47,11 +68,46 @@ func (d Degrees) F64() float64 {
 	return float64(d)
 }
 
+// ApproxEqual will return true if the two angles are within the tolerance of
+// each other (inclusive). This compares the values as they are, so 359 and
+// -1 are *not* considered to be close -- normalize the angles first if
+// that's what you're after.
+func (d Degrees) ApproxEqual(other Degrees, tolerance Degrees) bool {
+	return math.Abs((d - other).F64()) <= tolerance.F64()
+}
+
+// Round will return the angle rounded to the provided number of decimal
+// places, with halfway values rounded away from zero.
+func (d Degrees) Round(decimals int) Degrees {
+	return Degrees(roundDecimals(d.F64(), decimals))
+}
+
+// roundDecimals will round the value to the provided number of decimal
+// places. A negative number of decimals will round to the left of the
+// decimal point, so -2 will round to the nearest hundred.
+//
+// Dividing by the power of ten (rather than multiplying by its inverse) is
+// important here, since 10^-n can't be exactly represented, but 10^n can be,
+// which gets us the closest float64 to the decimal value.
+func roundDecimals(value float64, decimals int) float64 {
+	if decimals < 0 {
+		scale := math.Pow10(-decimals)
+		return math.Round(value/scale) * scale
+	}
+	scale := math.Pow10(decimals)
+	return math.Round(value*scale) / scale
+}
+
 // Radians returns the Angle, but in terms of Radians.
 func (d Degrees) Radians() Radians {
 	return Radians(math.Pi / 180 * d)
 }
 
+// Turns returns the Angle, but in terms of Turns.
+func (d Degrees) Turns() Turns {
+	return Turns(d / 360)
+}
+
 // Radians represents an angular measurement, in Radians. This type is for
 // two main reasons -- firstly, to enforce (on a type level) that the user is
 // aware that the angle measurements must be in Radians, and second, to bind
@@ -69,6 +125,34 @@ func (r Radians) Degrees() Degrees {
 	return Degrees(180 / math.Pi * r)
 }
 
+// Turns returns the Angle, but in terms of Turns.
+func (r Radians) Turns() Turns {
+	return Turns(r / (2 * math.Pi))
+}
+
+// Turns represents an angular measurement, in Turns (full revolutions), as
+// used by some rotary encoders and graphics code. This type is for the same
+// reasons as Degrees and Radians -- to enforce (on a type level) that the
+// user is aware that the angle measurements must be in Turns, and to bind
+// conversion helpers to Degrees and Radians.
+type Turns float64
+
+// F64 will return the value as a float64. Doing "value.F64()" is the same
+// as doing "float64(value)", except this can be a bit more clean at times.
+func (t Turns) F64() float64 {
+	return float64(t)
+}
+
+// Degrees returns the Angle, but in terms of Degrees.
+func (t Turns) Degrees() Degrees {
+	return Degrees(360 * t)
+}
+
+// Radians returns the Angle, but in terms of Radians.
+func (t Turns) Radians() Radians {
+	return Radians(2 * math.Pi * t)
+}
+
 // CoordinateSystem is a system to map locations (usually Latitude and
 // Longitude, in the form of LLA objects) to absolute points in space.
 //
@@ -118,6 +202,18 @@ type LLA struct {
 	Altitude  Meters
 }
 
+// ApproxEqual will return true if the two LLAs are the same place, give or
+// take the tolerances -- the Latitude and Longitude must each be within the
+// latLonTol, and the Altitude within the altTol.
+//
+// Unlike Degrees.ApproxEqual, the Longitude difference is wrapped, so 180
+// and -180 are treated as the same line of Longitude.
+func (lla LLA) ApproxEqual(other LLA, latLonTol Degrees, altTol Meters) bool {
+	return lla.Latitude.ApproxEqual(other.Latitude, latLonTol) &&
+		normalizeLongitude(lla.Longitude-other.Longitude).ApproxEqual(0, latLonTol) &&
+		lla.Altitude.ApproxEqual(other.Altitude, altTol)
+}
+
 // XYZ is the earth-centric XYZ point system LLA locations can be turned into
 // points on the Earth's ellipsoid, but plotted using cartesian coordinates
 // relative to Earth, rather than angular LLA measurements.
@@ -152,6 +248,67 @@ func (aed AER) ENU() ENU {
 	}
 }
 
+// UnitVector will return the direction of the AER as a unit vector in the
+// ENU tangent plane. The Range is ignored.
+func (aed AER) UnitVector() ENU {
+	return AER{
+		Azimuth:   aed.Azimuth,
+		Elevation: aed.Elevation,
+		Range:     1,
+	}.ENU()
+}
+
+// AngularSeparation will return the angle between the direction of this AER
+// and the direction of the other AER, ignoring the Range of both. This is
+// always within [0, 180].
+func (aed AER) AngularSeparation(other AER) Degrees {
+	var (
+		a = aed.UnitVector()
+		b = other.UnitVector()
+	)
+
+	return vector{x: a.East.F64(), y: a.North.F64(), z: a.Up.F64()}.angle(
+		vector{x: b.East.F64(), y: b.North.F64(), z: b.Up.F64()},
+	).Degrees()
+}
+
+// ZenithAngle will return the angle between straight up and the direction of
+// the AER, which is 90 Degrees less the Elevation. A target directly
+// overhead has a ZenithAngle of 0, and one on the horizon a ZenithAngle of
+// 90.
+func (aed AER) ZenithAngle() Degrees {
+	return 90 - aed.Elevation
+}
+
+// NadirAngle will return the angle, as seen from the target looking back at
+// the observer, between straight down (towards the center of the Earth) and
+// the direction of the observer. This is the off-nadir angle of a satellite
+// looking at a ground station, and is 0 when the target is directly
+// overhead.
+//
+// Unlike the ZenithAngle, this depends on the curve of the Earth, and uses
+// the Range, so this treats the Earth as a sphere (with the same radius
+// HaversineDistance uses), with the observer on the surface.
+func (aed AER) NadirAngle() Degrees {
+	var (
+		elevation = aed.Elevation.Radians().F64()
+		rho       = aed.Range.F64()
+		r         = earthRadiusMeters
+
+		// The distance from the center of the Earth to the target.
+		target = math.Sqrt(r*r + rho*rho + 2*r*rho*math.Sin(elevation))
+	)
+	return Radians(math.Asin(r * math.Cos(elevation) / target)).Degrees()
+}
+
+// UnitVectorToAER will convert an ENU direction vector back into an AER. The
+// vector doesn't need to be of unit length -- the Range of the returned AER
+// is the magnitude of the vector, so passing the output of AER.UnitVector
+// will return a Range of 1.
+func UnitVectorToAER(enu ENU) AER {
+	return enu.AER()
+}
+
 // AER will convert the 3D ENU point, and return it as an angular AER vector.
 func (enu ENU) AER() AER {
 	var (
@@ -166,4 +323,26 @@ func (enu ENU) AER() AER {
 	}
 }
 
+// HorizontalDistance will return the distance to the ENU point along the
+// tangent plane, ignoring the Up component entirely.
+func (enu ENU) HorizontalDistance() Meters {
+	return Meters(math.Hypot(enu.East.F64(), enu.North.F64()))
+}
+
+// Distance will return the straight-line distance to the ENU point, including
+// the Up component.
+func (enu ENU) Distance() Meters {
+	return Meters(math.Sqrt((enu.East*enu.East + enu.North*enu.North + enu.Up*enu.Up).F64()))
+}
+
+// Heading will return the direction of the ENU point along the tangent plane,
+// in Degrees clockwise from North, within [0, 360).
+func (enu ENU) Heading() Degrees {
+	heading := Radians(math.Atan2(enu.East.F64(), enu.North.F64())).Degrees()
+	if heading < 0 {
+		heading += 360
+	}
+	return heading
+}
+
 // vim: foldmethod=marker
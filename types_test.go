@@ -0,0 +1,142 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestENUDistanceAndHeading(t *testing.T) {
+	enu := geo.ENU{East: 1, North: 1}
+
+	assert.InEpsilon(t, 45, enu.Heading().F64(), 1e-9)
+	assert.InEpsilon(t, math.Sqrt2, enu.HorizontalDistance().F64(), 1e-9)
+	assert.InEpsilon(t, math.Sqrt2, enu.Distance().F64(), 1e-9)
+
+	enu = geo.ENU{East: 3, North: 4, Up: 12}
+	assert.InEpsilon(t, 5, enu.HorizontalDistance().F64(), 1e-9)
+	assert.InEpsilon(t, 13, enu.Distance().F64(), 1e-9)
+}
+
+func TestENUHeadingRange(t *testing.T) {
+	assert.Equal(t, 0.0, geo.ENU{North: 1}.Heading().F64())
+	assert.InEpsilon(t, 270, geo.ENU{East: -1}.Heading().F64(), 1e-9)
+	assert.InEpsilon(t, 315, geo.ENU{East: -1, North: 1}.Heading().F64(), 1e-9)
+}
+
+func TestAERUnitVector(t *testing.T) {
+	up := geo.AER{Azimuth: 0, Elevation: 90, Range: 1000}.UnitVector()
+	assert.InDelta(t, 0, up.East.F64(), 1e-12)
+	assert.InDelta(t, 0, up.North.F64(), 1e-12)
+	assert.InEpsilon(t, 1, up.Up.F64(), 1e-12)
+
+	aer := geo.UnitVectorToAER(geo.ENU{Up: 1})
+	assert.InEpsilon(t, 90, aer.Elevation.F64(), 1e-12)
+	assert.InEpsilon(t, 1, aer.Range.F64(), 1e-12)
+
+	look := geo.AER{Azimuth: 123, Elevation: 12, Range: 5000}
+	unit := look.UnitVector()
+	assert.InEpsilon(t, 1, unit.Distance().F64(), 1e-12)
+
+	aer = geo.UnitVectorToAER(unit)
+	assert.InEpsilon(t, look.Azimuth.F64(), aer.Azimuth.F64(), 1e-12)
+	assert.InEpsilon(t, look.Elevation.F64(), aer.Elevation.F64(), 1e-12)
+	assert.InEpsilon(t, 1, aer.Range.F64(), 1e-12)
+}
+
+func TestAERAngularSeparation(t *testing.T) {
+	look := geo.AER{Azimuth: 123, Elevation: 12, Range: 5000}
+	assert.InDelta(t, 0, look.AngularSeparation(look).F64(), 1e-12)
+	assert.InDelta(t, 0, look.AngularSeparation(geo.AER{Azimuth: 123, Elevation: 12, Range: 1}).F64(), 1e-12)
+
+	assert.InEpsilon(t, 90, geo.AER{Azimuth: 0}.AngularSeparation(geo.AER{Azimuth: 90}).F64(), 1e-12)
+	assert.InEpsilon(t, 90, geo.AER{Azimuth: 45}.AngularSeparation(geo.AER{Elevation: 90}).F64(), 1e-12)
+	assert.InEpsilon(t, 180, geo.AER{Azimuth: 10}.AngularSeparation(geo.AER{Azimuth: 190}).F64(), 1e-12)
+
+	// Across the wrap from 350 to 10 is 20 degrees, not 340.
+	assert.InEpsilon(t, 20, geo.AER{Azimuth: 350}.AngularSeparation(geo.AER{Azimuth: 10}).F64(), 1e-12)
+	assert.InEpsilon(t, 90, geo.AER{Azimuth: 315}.AngularSeparation(geo.AER{Azimuth: 45}).F64(), 1e-12)
+}
+
+func TestAERZenithNadirAngle(t *testing.T) {
+	overhead := geo.AER{Azimuth: 123, Elevation: 90, Range: 500000}
+	assert.Equal(t, geo.Degrees(0), overhead.ZenithAngle())
+	assert.InDelta(t, 0, overhead.NadirAngle().F64(), 1e-6)
+
+	assert.Equal(t, geo.Degrees(60), geo.AER{Elevation: 30}.ZenithAngle())
+
+	// A satellite 500km up, right on the horizon, is looking back at the
+	// observer along the tangent to the Earth.
+	var (
+		r       = 6371000.0
+		h       = 500000.0
+		horizon = geo.AER{Elevation: 0, Range: geo.Meters(math.Sqrt((r+h)*(r+h) - r*r))}
+	)
+	assert.Equal(t, geo.Degrees(90), horizon.ZenithAngle())
+	assert.InEpsilon(t,
+		geo.Radians(math.Asin(r/(r+h))).Degrees().F64(),
+		horizon.NadirAngle().F64(),
+		1e-9,
+	)
+}
+
+func TestMetersApproxEqual(t *testing.T) {
+	assert.True(t, geo.Meters(100).ApproxEqual(100, 0))
+	assert.True(t, geo.Meters(100).ApproxEqual(100.009, 0.01))
+	assert.True(t, geo.Meters(100).ApproxEqual(99.991, 0.01))
+	assert.False(t, geo.Meters(100).ApproxEqual(100.011, 0.01))
+	assert.False(t, geo.Meters(100).ApproxEqual(99.989, 0.01))
+}
+
+func TestDegreesApproxEqual(t *testing.T) {
+	assert.True(t, geo.Degrees(38.8979).ApproxEqual(38.8979, 0))
+	assert.True(t, geo.Degrees(38.8979).ApproxEqual(38.89799, 1e-4))
+	assert.False(t, geo.Degrees(38.8979).ApproxEqual(38.89801, 1e-4))
+	assert.False(t, geo.Degrees(-38.8979).ApproxEqual(38.8979, 1e-4))
+	assert.False(t, geo.Degrees(359.99).ApproxEqual(-0.01, 0.1))
+}
+
+func TestLLAApproxEqual(t *testing.T) {
+	var (
+		wgs = geo.WGS84()
+		lla = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30}
+	)
+
+	back := wgs.XYZToLLA(wgs.LLAToXYZ(lla))
+	assert.True(t, lla.ApproxEqual(back, 1e-9, 1e-3))
+
+	assert.False(t, lla.ApproxEqual(geo.LLA{Latitude: 38.8981, Longitude: -77.036560, Altitude: 30}, 1e-4, 1e-3))
+	assert.False(t, lla.ApproxEqual(geo.LLA{Latitude: 38.897957, Longitude: -77.0367, Altitude: 30}, 1e-4, 1e-3))
+	assert.False(t, lla.ApproxEqual(geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 31}, 1e-4, 0.5))
+
+	assert.True(t, geo.LLA{Latitude: 10, Longitude: 180}.ApproxEqual(geo.LLA{Latitude: 10, Longitude: -180}, 1e-9, 0))
+	assert.True(t, geo.LLA{Latitude: 10, Longitude: 179.99999}.ApproxEqual(geo.LLA{Latitude: 10, Longitude: -179.99999}, 1e-4, 0))
+}
+
+func TestDegreesRound(t *testing.T) {
+	assert.Equal(t, geo.Degrees(38.898), geo.Degrees(38.8979557).Round(4))
+	assert.Equal(t, geo.Degrees(-77.0366), geo.Degrees(-77.036560).Round(4))
+	assert.Equal(t, geo.Degrees(38.897956), geo.Degrees(38.8979557).Round(6))
+	assert.Equal(t, geo.Degrees(39), geo.Degrees(38.8979557).Round(0))
+	assert.Equal(t, geo.Degrees(0.3), geo.Degrees(0.1+0.2).Round(10))
+}
+
+func TestMetersRound(t *testing.T) {
+	assert.Equal(t, geo.Meters(1234.57), geo.Meters(1234.5678).Round(2))
+	assert.Equal(t, geo.Meters(-2.5), geo.Meters(-2.45).Round(1))
+	assert.Equal(t, geo.Meters(1200), geo.Meters(1234.5678).Round(-2))
+}
+
+func TestTurns(t *testing.T) {
+	quarter := geo.Turns(0.25)
+	assert.InEpsilon(t, 90, quarter.Degrees().F64(), 1e-12)
+	assert.InEpsilon(t, math.Pi/2, quarter.Radians().F64(), 1e-12)
+
+	assert.InEpsilon(t, 0.25, geo.Degrees(90).Turns().F64(), 1e-12)
+	assert.InEpsilon(t, 0.25, geo.Radians(math.Pi/2).Turns().F64(), 1e-12)
+	assert.InEpsilon(t, -1.5, geo.Degrees(-540).Turns().F64(), 1e-12)
+	assert.InEpsilon(t, 2.0, geo.Turns(2).Degrees().Turns().F64(), 1e-12)
+}
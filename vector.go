@@ -0,0 +1,115 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// vector is a unitless cartesian vector. This is used internally to do
+// spherical geometry on unit vectors, which tends to be a lot less painful
+// (and a lot less sensitive to the poles and the anti-meridian) than working
+// with Latitude and Longitude directly.
+type vector struct {
+	x, y, z float64
+}
+
+// unitVector will return the unit vector pointing at the Latitude and
+// Longitude of the provided LLA, treating Earth as a sphere. Altitude is
+// ignored.
+func unitVector(l LLA) vector {
+	var (
+		lat = l.Latitude.Radians().F64()
+		lon = l.Longitude.Radians().F64()
+	)
+
+	return vector{
+		x: math.Cos(lat) * math.Cos(lon),
+		y: math.Cos(lat) * math.Sin(lon),
+		z: math.Sin(lat),
+	}
+}
+
+// lla will return the Latitude and Longitude the vector is pointing at, with
+// an Altitude of 0.
+func (v vector) lla() LLA {
+	return LLA{
+		Latitude:  Radians(math.Atan2(v.z, math.Sqrt(v.x*v.x+v.y*v.y))).Degrees(),
+		Longitude: Radians(math.Atan2(v.y, v.x)).Degrees(),
+	}
+}
+
+// vector will return the XYZ as a vector, in Meters.
+func (x XYZ) vector() vector {
+	return vector{x: x.X.F64(), y: x.Y.F64(), z: x.Z.F64()}
+}
+
+// xyz will return the vector as an XYZ, treating each component as Meters.
+func (v vector) xyz() XYZ {
+	return XYZ{X: Meters(v.x), Y: Meters(v.y), Z: Meters(v.z)}
+}
+
+func (v vector) add(o vector) vector {
+	return vector{x: v.x + o.x, y: v.y + o.y, z: v.z + o.z}
+}
+
+func (v vector) sub(o vector) vector {
+	return vector{x: v.x - o.x, y: v.y - o.y, z: v.z - o.z}
+}
+
+func (v vector) scale(s float64) vector {
+	return vector{x: v.x * s, y: v.y * s, z: v.z * s}
+}
+
+func (v vector) dot(o vector) float64 {
+	return v.x*o.x + v.y*o.y + v.z*o.z
+}
+
+func (v vector) cross(o vector) vector {
+	return vector{
+		x: v.y*o.z - v.z*o.y,
+		y: v.z*o.x - v.x*o.z,
+		z: v.x*o.y - v.y*o.x,
+	}
+}
+
+func (v vector) norm() float64 {
+	return math.Sqrt(v.dot(v))
+}
+
+// unit will return the vector scaled to a length of 1. The zero vector is
+// returned unchanged, since it doesn't have a direction to speak of.
+func (v vector) unit() vector {
+	n := v.norm()
+	if n == 0 {
+		return v
+	}
+	return v.scale(1 / n)
+}
+
+// angle will return the angle between the two vectors. This uses atan2 rather
+// than acos of the dot product, since acos is terribly imprecise for the very
+// small (and very large) angles we tend to care about.
+func (v vector) angle(o vector) Radians {
+	return Radians(math.Atan2(v.cross(o).norm(), v.dot(o)))
+}
+
+// vim: foldmethod=marker
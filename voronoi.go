@@ -0,0 +1,46 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+// NearestSite will return the index of the site closest to the point, along
+// with the great-circle distance to it. Which is to say, this will return
+// which cell of the spherical Voronoi diagram of the sites the point falls
+// into, without having to build the diagram itself.
+//
+// This uses the same spherical Earth as HaversineDistance, and ignores
+// Altitude. If two sites are exactly as far away, the first one wins. If
+// there are no sites, an index of -1 is returned.
+func NearestSite(point LLA, sites []LLA) (int, Meters) {
+	var (
+		index    = -1
+		distance Meters
+	)
+
+	for i, site := range sites {
+		if d := haversine(point, site); index < 0 || d < distance {
+			index = i
+			distance = d
+		}
+	}
+	return index, distance
+}
+
+// vim: foldmethod=marker
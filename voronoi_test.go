@@ -0,0 +1,39 @@
+package geo_test
+
+import (
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNearestSite(t *testing.T) {
+	sites := []geo.LLA{
+		{Latitude: 51.510357, Longitude: -0.116773},
+		{Latitude: 38.889931, Longitude: -77.009003},
+		{Latitude: 35.6762, Longitude: 139.6503},
+	}
+
+	point := geo.LLA{Latitude: 40.7128, Longitude: -74.0060, Altitude: 100}
+	index, distance := geo.NearestSite(point, sites)
+	assert.Equal(t, 1, index)
+
+	expected, err := geo.HaversineDistance(
+		geo.LLA{Latitude: 40.7128, Longitude: -74.0060},
+		sites[1],
+	)
+	assert.NoError(t, err)
+	assert.InEpsilon(t, expected.F64(), distance.F64(), 1e-12)
+
+	// Across the anti-meridian from Tokyo.
+	index, _ = geo.NearestSite(geo.LLA{Latitude: 30, Longitude: -170}, sites)
+	assert.Equal(t, 2, index)
+
+	index, distance = geo.NearestSite(sites[0], sites)
+	assert.Equal(t, 0, index)
+	assert.Equal(t, geo.Meters(0), distance)
+
+	index, _ = geo.NearestSite(point, nil)
+	assert.Equal(t, -1, index)
+}
@@ -53,29 +53,77 @@ func WGS84() CoordinateSystem {
 type wgs84 struct{}
 
 func (w wgs84) XYZToLLA(x XYZ) LLA {
+	return XYZToLLAWithOptions(x, DefaultConvergenceOptions)
+}
+
+// ConvergenceOptions control how hard XYZToLLAWithOptions tries to converge
+// on a solution.
+type ConvergenceOptions struct {
+	// Tolerance is the change in Latitude between two iterations small
+	// enough for the solution to be considered converged. If this isn't
+	// positive, the Tolerance from DefaultConvergenceOptions is used.
+	Tolerance Radians
+
+	// MaxIterations is the most iterations that will be run, regardless of
+	// if the solution has converged. If this isn't positive, the
+	// MaxIterations from DefaultConvergenceOptions is used.
+	MaxIterations int
+}
+
+// DefaultConvergenceOptions are the ConvergenceOptions used when a field is
+// left unset. A tolerance of 1e-11 Radians is well under a millimeter on the
+// surface of the Earth, and 5 iterations is enough to reach it for anything
+// short of deep space.
+var DefaultConvergenceOptions = ConvergenceOptions{
+	Tolerance:     1e-11,
+	MaxIterations: 5,
+}
+
+// XYZToLLAWithOptions will convert the XYZ into a WGS84 LLA, iterating on the
+// Latitude until it converges as requested by the ConvergenceOptions.
+//
+// The WGS84 CoordinateSystem's XYZToLLA uses the DefaultConvergenceOptions.
+// Calling this directly can trade off speed and precision -- fewer
+// iterations for embedded targets that are fine with "close enough", or a
+// tighter tolerance for precision-critical work (or points at a very high
+// Altitude).
+func XYZToLLAWithOptions(x XYZ, opts ConvergenceOptions) LLA {
+	if opts.Tolerance <= 0 || math.IsNaN(opts.Tolerance.F64()) {
+		opts.Tolerance = DefaultConvergenceOptions.Tolerance
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = DefaultConvergenceOptions.MaxIterations
+	}
 
 	var (
-		eps   = wgs84ESq / (1 - wgs84ESq)
-		p     = math.Sqrt((x.X*x.X + x.Y*x.Y).F64())
-		q     = math.Atan2((x.Z.F64() * wgs84A), (p * wgs84B))
-		sinQ  = math.Sin(q)
-		cosQ  = math.Cos(q)
-		sinQ3 = sinQ * sinQ * sinQ
-		cosQ3 = cosQ * cosQ * cosQ
-
-		phi = math.Atan2(
-			(x.Z.F64() + eps*wgs84B*sinQ3),
-			(p - wgs84ESq*wgs84A*cosQ3),
-		)
+		p      = math.Sqrt((x.X*x.X + x.Y*x.Y).F64())
+		z      = x.Z.F64()
 		lambda = math.Atan2(x.Y.F64(), x.X.F64())
-		v      = wgs84A / math.Sqrt(1.0-wgs84ESq*math.Sin(phi)*math.Sin(phi))
-		h      = Meters((p / math.Cos(phi)) - v)
+
+		// Start off assuming the point is on the surface.
+		phi = math.Atan2(z, p*(1-wgs84ESq))
 	)
 
+	for i := 0; i < opts.MaxIterations; i++ {
+		var (
+			sinPhi = math.Sin(phi)
+			n      = wgs84A / math.Sqrt(1-wgs84ESq*sinPhi*sinPhi)
+			h      = p*math.Cos(phi) + z*sinPhi - wgs84A*wgs84A/n
+			next   = math.Atan2(z, p*(1-wgs84ESq*n/(n+h)))
+			delta  = math.Abs(next - phi)
+		)
+
+		phi = next
+		if delta < opts.Tolerance.F64() {
+			break
+		}
+	}
+
+	sinPhi := math.Sin(phi)
 	return LLA{
 		Latitude:  Radians(phi).Degrees(),
 		Longitude: Radians(lambda).Degrees(),
-		Altitude:  h,
+		Altitude:  Meters(p*math.Cos(phi) + z*sinPhi - wgs84A*math.Sqrt(1-wgs84ESq*sinPhi*sinPhi)),
 	}
 }
 
@@ -105,64 +153,11 @@ func (w wgs84) LLAToENU(ref, lla LLA) ENU {
 }
 
 func (w wgs84) XYZToENU(ref LLA, e XYZ) ENU {
-	var (
-		lambda = ref.Latitude.Radians().F64()
-		phi    = ref.Longitude.Radians().F64()
-
-		sinLambda = math.Sin(lambda)
-		cosLambda = math.Cos(lambda)
-		sinPhi    = math.Sin(phi)
-		cosPhi    = math.Cos(phi)
-
-		xref = w.LLAToXYZ(ref)
-
-		xd = (e.X - xref.X).F64()
-		yd = (e.Y - xref.Y).F64()
-		zd = (e.Z - xref.Z).F64()
-	)
-
-	return ENU{
-		East:  Meters(-sinPhi*xd + cosPhi*yd),
-		North: Meters(-cosPhi*sinLambda*xd - sinLambda*sinPhi*yd + cosLambda*zd),
-		Up:    Meters(cosLambda*cosPhi*xd + cosLambda*sinPhi*yd + sinLambda*zd),
-	}
+	return xyzToENU(ref, w.LLAToXYZ(ref), e)
 }
 
 func (w wgs84) ENUToXYZ(ref LLA, e ENU) XYZ {
-	var (
-		lambda = ref.Latitude.Radians().F64()
-		phi    = ref.Longitude.Radians().F64()
-		s      = math.Sin(lambda)
-		n      = wgs84A / math.Sqrt(1-wgs84ESq*s*s)
-
-		sinLambda = math.Sin(lambda)
-		cosLambda = math.Cos(lambda)
-
-		sinPhi = math.Sin(phi)
-		cosPhi = math.Cos(phi)
-
-		x0 = (ref.Altitude.F64() + n) * cosLambda * cosPhi
-		y0 = (ref.Altitude.F64() + n) * cosLambda * sinPhi
-		z0 = (ref.Altitude.F64() + (1-wgs84ESq)*n) * sinLambda
-
-		east  = e.East.F64()
-		north = e.North.F64()
-		up    = e.Up.F64()
-
-		xd = -sinPhi*east - cosPhi*sinLambda*north + cosLambda*cosPhi*up
-		yd = cosPhi*east - sinLambda*sinPhi*north + cosLambda*sinPhi*up
-		zd = cosLambda*north + sinLambda*up
-
-		x = xd + x0
-		y = yd + y0
-		z = zd + z0
-	)
-
-	return XYZ{
-		X: Meters(x),
-		Y: Meters(y),
-		Z: Meters(z),
-	}
+	return enuToXYZ(ref, w.LLAToXYZ(ref), e)
 }
 
 // vim: foldmethod=marker
@@ -1,6 +1,7 @@
 package geo_test
 
 import (
+	"math"
 	"testing"
 
 	"pault.ag/go/geo"
@@ -133,3 +134,207 @@ func TestWGS84AERENURoundTrip(t *testing.T) {
 	assert.InEpsilon(t, float64(position.Longitude), float64(position1.Longitude), 1e-7)
 	assert.InEpsilon(t, float64(position.Altitude), float64(position1.Altitude), 1e-7)
 }
+
+func TestWGS84OffsetLLA(t *testing.T) {
+	wgs84 := geo.WGS84()
+	origin := geo.LLA{
+		Latitude:  38.897957,
+		Longitude: -77.036560,
+		Altitude:  30,
+	}
+
+	east := geo.OffsetLLA(wgs84, origin, geo.ENU{East: 50})
+	assert.InDelta(t, origin.Latitude.F64(), east.Latitude.F64(), 1e-8)
+	assert.Greater(t, east.Longitude.F64(), origin.Longitude.F64())
+
+	enu := wgs84.LLAToENU(origin, east)
+	assert.InEpsilon(t, 50, enu.East.F64(), 1e-6)
+	assert.InDelta(t, 0, enu.North.F64(), 1e-6)
+	assert.InDelta(t, 0, enu.Up.F64(), 1e-6)
+
+	moved := geo.OffsetLLA(wgs84, origin, geo.ENU{East: 50, North: 20})
+	enu = wgs84.LLAToENU(origin, moved)
+	assert.InEpsilon(t, 50, enu.East.F64(), 1e-6)
+	assert.InEpsilon(t, 20, enu.North.F64(), 1e-6)
+}
+
+func TestWGS84ENUToLLA(t *testing.T) {
+	wgs84 := geo.WGS84()
+	ref := geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 30}
+
+	// Up is along the normal to the ellipsoid, so going straight up should
+	// keep the Latitude and Longitude, and only change the Altitude.
+	above := geo.ENUToLLA(wgs84, ref, geo.ENU{Up: 400000})
+	assert.InDelta(t, ref.Latitude.F64(), above.Latitude.F64(), 1e-9)
+	assert.InDelta(t, ref.Longitude.F64(), above.Longitude.F64(), 1e-9)
+	assert.InDelta(t, 400030, above.Altitude.F64(), 1e-3)
+
+	// And off to the side, it should make it back to the same ENU.
+	e := geo.ENU{East: 120000, North: -80000, Up: 400000}
+	enu := wgs84.LLAToENU(ref, geo.ENUToLLA(wgs84, ref, e))
+	assert.InDelta(t, e.East.F64(), enu.East.F64(), 1e-3)
+	assert.InDelta(t, e.North.F64(), enu.North.F64(), 1e-3)
+	assert.InDelta(t, e.Up.F64(), enu.Up.F64(), 1e-3)
+
+	assert.Equal(t, geo.ENUToLLA(wgs84, ref, e), geo.OffsetLLA(wgs84, ref, e))
+}
+
+func TestXYZToLLAWithOptions(t *testing.T) {
+	wgs84 := geo.WGS84()
+	position := geo.LLA{
+		Latitude:  38.897957,
+		Longitude: -77.036560,
+		Altitude:  100,
+	}
+
+	position1 := geo.XYZToLLAWithOptions(wgs84.LLAToXYZ(position), geo.ConvergenceOptions{})
+	assert.InEpsilon(t, float64(position.Latitude), float64(position1.Latitude), 1e-9)
+	assert.InEpsilon(t, float64(position.Longitude), float64(position1.Longitude), 1e-9)
+	assert.InEpsilon(t, float64(position.Altitude), float64(position1.Altitude), 1e-6)
+
+	x := wgs84.LLAToXYZ(position)
+	assert.Equal(t, geo.XYZToLLAWithOptions(x, geo.DefaultConvergenceOptions), wgs84.XYZToLLA(x))
+}
+
+func TestXYZToLLAWithOptionsTolerance(t *testing.T) {
+	wgs84 := geo.WGS84()
+	position := geo.LLA{
+		Latitude:  45,
+		Longitude: 10,
+		Altitude:  20200000,
+	}
+	x := wgs84.LLAToXYZ(position)
+
+	residual := func(opts geo.ConvergenceOptions) float64 {
+		return math.Abs((geo.XYZToLLAWithOptions(x, opts).Latitude - position.Latitude).F64())
+	}
+
+	loose := residual(geo.ConvergenceOptions{Tolerance: 1e-3, MaxIterations: 100})
+	tight := residual(geo.ConvergenceOptions{Tolerance: 1e-14, MaxIterations: 100})
+	single := residual(geo.ConvergenceOptions{MaxIterations: 1})
+
+	assert.Less(t, tight, loose)
+	assert.Less(t, tight, single)
+	assert.Less(t, tight, 1e-10)
+
+	// Settings that aren't positive fall back to the defaults, rather than
+	// skipping the iterations entirely.
+	defaults := geo.XYZToLLAWithOptions(x, geo.DefaultConvergenceOptions)
+	assert.Equal(t, defaults, geo.XYZToLLAWithOptions(x, geo.ConvergenceOptions{MaxIterations: -1}))
+	assert.Equal(t, defaults, geo.XYZToLLAWithOptions(x, geo.ConvergenceOptions{Tolerance: -1}))
+}
+
+func TestWGS84SubPoint(t *testing.T) {
+	wgs84 := geo.WGS84()
+	satellite := wgs84.LLAToXYZ(geo.LLA{
+		Latitude:  38.897957,
+		Longitude: -77.036560,
+		Altitude:  408000,
+	})
+
+	sub := geo.SubPoint(wgs84, satellite)
+	assert.InEpsilon(t, 38.897957, sub.Latitude.F64(), 1e-9)
+	assert.InEpsilon(t, -77.036560, sub.Longitude.F64(), 1e-9)
+	assert.Equal(t, geo.Meters(0), sub.Altitude)
+}
+
+func distance(a, b geo.XYZ) float64 {
+	return math.Sqrt(((a.X-b.X)*(a.X-b.X) + (a.Y-b.Y)*(a.Y-b.Y) + (a.Z-b.Z)*(a.Z-b.Z)).F64())
+}
+
+func TestWGS84NearestSurfacePoint(t *testing.T) {
+	wgs84 := geo.WGS84()
+	surface := geo.LLA{Latitude: 45, Longitude: 10}
+	above := geo.LLA{Latitude: 45, Longitude: 10, Altitude: 100000}
+
+	// Straight up along the surface normal comes straight back down.
+	foot := geo.NearestSurfacePoint(wgs84, wgs84.LLAToXYZ(above))
+	assert.InDelta(t, 0, distance(foot, wgs84.LLAToXYZ(surface)), 1e-6)
+
+	// Nudge the point off of that normal, and compare to just scaling the
+	// point down onto the ellipsoid.
+	point := wgs84.LLAToXYZ(above)
+	point.X += 5000
+	nearest := geo.NearestSurfacePoint(wgs84, point)
+
+	var (
+		a = 6378137.0
+		b = 6356752.314245
+
+		scale = 1 / math.Sqrt(((point.X*point.X+point.Y*point.Y).F64())/(a*a)+(point.Z*point.Z).F64()/(b*b))
+
+		radial = geo.XYZ{
+			X: point.X * geo.Meters(scale),
+			Y: point.Y * geo.Meters(scale),
+			Z: point.Z * geo.Meters(scale),
+		}
+	)
+
+	assert.InDelta(t, 0, wgs84.XYZToLLA(nearest).Altitude.F64(), 1e-6)
+	assert.InDelta(t, 0, wgs84.XYZToLLA(radial).Altitude.F64(), 1e-6)
+	assert.Less(t, distance(point, nearest), distance(point, radial))
+}
+
+func TestWGS84RayToSurface(t *testing.T) {
+	wgs84 := geo.WGS84()
+	observer := geo.LLA{
+		Latitude:  38.897957,
+		Longitude: -77.036560,
+		Altitude:  10000,
+	}
+
+	// Straight down lands right under the observer.
+	ground, err := geo.RayToSurface(wgs84, observer, geo.AER{Elevation: -90})
+	assert.NoError(t, err)
+	assert.InEpsilon(t, observer.Latitude.F64(), ground.Latitude.F64(), 1e-9)
+	assert.InEpsilon(t, observer.Longitude.F64(), ground.Longitude.F64(), 1e-9)
+	assert.Equal(t, geo.Meters(0), ground.Altitude)
+
+	// 45 Degrees down, off to the East, lands about 10km East.
+	ground, err = geo.RayToSurface(wgs84, observer, geo.AER{Azimuth: 90, Elevation: -45})
+	assert.NoError(t, err)
+	enu := wgs84.LLAToENU(geo.LLA{Latitude: observer.Latitude, Longitude: observer.Longitude}, ground)
+	assert.InEpsilon(t, 10000, enu.East.F64(), 1e-2)
+	assert.InDelta(t, 0, enu.North.F64(), 1)
+
+	// Looking above the horizon never comes back down.
+	_, err = geo.RayToSurface(wgs84, observer, geo.AER{Azimuth: 90, Elevation: 10})
+	assert.Error(t, err)
+
+	// Looking down, but not enough to get below the horizon.
+	_, err = geo.RayToSurface(wgs84, observer, geo.AER{Azimuth: 90, Elevation: -1})
+	assert.Error(t, err)
+}
+
+func TestWGS84ENUBasis(t *testing.T) {
+	var (
+		wgs = geo.WGS84()
+		ref = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+	)
+
+	east, north, up := geo.ENUBasis(wgs, ref)
+	dot := func(a, b geo.XYZ) float64 {
+		return (a.X*b.X + a.Y*b.Y + a.Z*b.Z).F64()
+	}
+
+	for _, v := range []geo.XYZ{east, north, up} {
+		assert.InEpsilon(t, 1, norm(v), 1e-12)
+	}
+	assert.InDelta(t, 0, dot(east, north), 1e-12)
+	assert.InDelta(t, 0, dot(east, up), 1e-12)
+	assert.InDelta(t, 0, dot(north, up), 1e-12)
+
+	// Up should be the surface normal, which is to say, a meter up lands
+	// right where the basis vector says it will.
+	var (
+		origin = wgs.LLAToXYZ(ref)
+		above  = wgs.LLAToXYZ(geo.LLA{Latitude: ref.Latitude, Longitude: ref.Longitude, Altitude: 1})
+	)
+	assert.InDelta(t, up.X.F64(), (above.X - origin.X).F64(), 1e-6)
+	assert.InDelta(t, up.Y.F64(), (above.Y - origin.Y).F64(), 1e-6)
+	assert.InDelta(t, up.Z.F64(), (above.Z - origin.Z).F64(), 1e-6)
+
+	// And it's the same rotation as ENUToXYZ.
+	offset := wgs.ENUToXYZ(ref, geo.ENU{North: 1})
+	assert.InDelta(t, north.Z.F64(), (offset.Z - origin.Z).F64(), 1e-6)
+}
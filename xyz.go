@@ -0,0 +1,98 @@
+// {{{ Copyright (c) Paul R. Tagliamonte <paultag@gmail.com> 2020-2021
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE. }}}
+
+package geo
+
+import (
+	"math"
+)
+
+// xyzDistance will return the straight-line distance between the two points.
+func xyzDistance(a, b XYZ) Meters {
+	return Meters(a.vector().sub(b.vector()).norm())
+}
+
+// ChordDistance will return the straight-line distance between the two
+// points, cutting through the Earth rather than following its surface, using
+// the provided CoordinateSystem to turn them into XYZ points.
+//
+// Unlike HaversineDistance, this takes the Altitude of both points into
+// account, so two points at the same Latitude and Longitude will be as far
+// apart as their Altitudes are.
+func ChordDistance(cs CoordinateSystem, a, b LLA) Meters {
+	return xyzDistance(cs.LLAToXYZ(a), cs.LLAToXYZ(b))
+}
+
+// SubPoint will return the point on the surface of the CoordinateSystem
+// directly below the provided XYZ (such as a satellite), which is to say,
+// the LLA of the XYZ with the Altitude set to 0.
+func SubPoint(cs CoordinateSystem, x XYZ) LLA {
+	lla := cs.XYZToLLA(x)
+	lla.Altitude = 0
+	return lla
+}
+
+// NearestSurfacePoint will return the point on the surface of the
+// CoordinateSystem that is closest to the provided XYZ. This is the foot of
+// the surface normal through the XYZ, which (since the surface normal of an
+// ellipsoid doesn't point at the center of the Earth) is not the same as
+// scaling the XYZ down to the surface.
+func NearestSurfacePoint(cs CoordinateSystem, x XYZ) XYZ {
+	return cs.LLAToXYZ(SubPoint(cs, x))
+}
+
+// SlerpXYZ will spherically interpolate between the two XYZ points, where a
+// fraction of 0 returns a, and a fraction of 1 returns b.
+//
+// Unlike linear interpolation (which would cut through the Earth), the
+// direction of the returned point is swept along the great circle between
+// a and b at a constant rate, and its distance from the center of the Earth
+// is linearly interpolated between the distances of a and b. Two points on a
+// sphere will therefore always interpolate to a point on that same sphere.
+//
+// Points that are on exactly opposite sides of the Earth don't have a single
+// great circle between them, so they are linearly interpolated instead.
+func SlerpXYZ(a, b XYZ, fraction float64) XYZ {
+	var (
+		va = a.vector()
+		vb = b.vector()
+
+		ra = va.norm()
+		rb = vb.norm()
+
+		theta  = va.angle(vb).F64()
+		sin    = math.Sin(theta)
+		radius = ra + fraction*(rb-ra)
+	)
+
+	if ra == 0 || rb == 0 || sin < 1e-12 {
+		if theta > math.Pi/2 {
+			return va.add(vb.sub(va).scale(fraction)).xyz()
+		}
+		return va.unit().scale(radius).xyz()
+	}
+
+	direction := va.unit().scale(math.Sin((1-fraction)*theta) / sin).add(
+		vb.unit().scale(math.Sin(fraction*theta) / sin),
+	)
+	return direction.scale(radius).xyz()
+}
+
+// vim: foldmethod=marker
@@ -0,0 +1,63 @@
+package geo_test
+
+import (
+	"math"
+	"testing"
+
+	"pault.ag/go/geo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func norm(x geo.XYZ) float64 {
+	return math.Sqrt((x.X*x.X + x.Y*x.Y + x.Z*x.Z).F64())
+}
+
+func TestSlerpXYZ(t *testing.T) {
+	a := geo.XYZ{X: 6371000}
+	b := geo.XYZ{Y: 6371000}
+
+	mid := geo.SlerpXYZ(a, b, 0.5)
+	assert.InEpsilon(t, 6371000, norm(mid), 1e-12)
+	assert.InEpsilon(t, 6371000/math.Sqrt2, mid.X.F64(), 1e-12)
+	assert.InEpsilon(t, 6371000/math.Sqrt2, mid.Y.F64(), 1e-12)
+
+	start := geo.SlerpXYZ(a, b, 0)
+	assert.InEpsilon(t, a.X.F64(), start.X.F64(), 1e-12)
+	end := geo.SlerpXYZ(a, b, 1)
+	assert.InEpsilon(t, b.Y.F64(), end.Y.F64(), 1e-12)
+}
+
+func TestSlerpXYZRadius(t *testing.T) {
+	wgs84 := geo.WGS84()
+	a := wgs84.LLAToXYZ(geo.LLA{Latitude: 38.897957, Longitude: -77.036560})
+	b := wgs84.LLAToXYZ(geo.LLA{Latitude: 51.510357, Longitude: -0.116773, Altitude: 10000})
+
+	mid := geo.SlerpXYZ(a, b, 0.5)
+	assert.InEpsilon(t, (norm(a)+norm(b))/2, norm(mid), 1e-12)
+
+	// The linear midpoint cuts through the Earth, and is much closer to the
+	// center than either of the two points.
+	linear := geo.XYZ{X: (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2, Z: (a.Z + b.Z) / 2}
+	assert.Less(t, norm(linear), norm(mid)-100000)
+}
+
+func TestChordDistance(t *testing.T) {
+	var (
+		wgs    = geo.WGS84()
+		ground = geo.LLA{Latitude: 38.897957, Longitude: -77.036560}
+		above  = geo.LLA{Latitude: 38.897957, Longitude: -77.036560, Altitude: 1500}
+	)
+
+	assert.InEpsilon(t, 1500, geo.ChordDistance(wgs, ground, above).F64(), 1e-9)
+	assert.InEpsilon(t, 1500, geo.ChordDistance(wgs, above, ground).F64(), 1e-9)
+	assert.Equal(t, 0.0, geo.ChordDistance(wgs, above, above).F64())
+
+	// On a sphere, points 90 degrees apart are a radius * sqrt(2) apart.
+	sphere := geo.Sphere(1000)
+	assert.InEpsilon(t, 1000*math.Sqrt2, geo.ChordDistance(
+		sphere,
+		geo.LLA{Latitude: 0, Longitude: 0},
+		geo.LLA{Latitude: 0, Longitude: 90},
+	).F64(), 1e-12)
+}